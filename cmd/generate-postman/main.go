@@ -33,6 +33,10 @@ func main() {
 	routes.RegisterDirectoryRoutes(router)
 	routes.RegisterPermissionRoutes(router)
 	routes.RegisterNewsletterRoutes(router)
+	routes.RegisterIngestionRoutes(router)
+	routes.RegisterMediaRoutes(router)
+	routes.RegisterRegionRoutes(router)
+	routes.RegisterFeedRoutes(router)
 
 	if *generatePostman {
 		// Generate Postman collection
@@ -66,6 +66,10 @@ func main() {
 	routes.RegisterPermissionRoutes(router)
 	routes.RegisterNewsletterRoutes(router)
 	routes.RegisterPostmanRoutes(router)
+	routes.RegisterIngestionRoutes(router)
+	routes.RegisterMediaRoutes(router)
+	routes.RegisterRegionRoutes(router)
+	routes.RegisterFeedRoutes(router)
 
 	// Migrate sources from CSV on startup
 	// MigrateSources(serviceManager.Source, "bin/sources.csv")
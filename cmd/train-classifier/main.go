@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"vuka-api/pkg/config"
+	"vuka-api/pkg/services"
+)
+
+// Retrains the category classifier from articles whose feeds supplied
+// categories. Run after changing the category mapping or periodically as
+// the archive grows; running API instances pick up the new model within
+// half an hour.
+func main() {
+	config.LoadEnvVariables()
+	config.Connect()
+
+	serviceManager := services.NewServices(config.GetDB())
+
+	fmt.Println("Training category classifier...")
+	result, err := serviceManager.Classifier.Train()
+	if err != nil {
+		log.Fatalf("Training failed: %v", err)
+	}
+
+	names := make([]string, 0, len(result.Classes))
+	for name := range result.Classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("Trained on %d articles:\n", result.Documents)
+	for _, name := range names {
+		fmt.Printf("  %-20s %d\n", name, result.Classes[name])
+	}
+	fmt.Println("Classifier saved.")
+}
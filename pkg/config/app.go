@@ -15,7 +15,7 @@ var (
 func Connect() {
 	var err error
 	dsn := os.Getenv("CONNECTION_STRING") //datasource name
-	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatal("Failed to connect to database", err)
 	}
@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"vuka-api/pkg/config"
 	"vuka-api/pkg/httpx"
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
 	"vuka-api/pkg/services"
 	"vuka-api/pkg/utils"
@@ -13,6 +15,7 @@ import (
 
 type ArticleController struct {
 	articleService *services.ArticleService
+	clusterService *services.ClusterService
 	rssService     *services.RssService
 }
 
@@ -20,6 +23,7 @@ func NewArticleController() *ArticleController {
 	serviceManager := services.NewServices(config.GetDB())
 	return &ArticleController{
 		articleService: serviceManager.Article,
+		clusterService: serviceManager.Cluster,
 		rssService:     serviceManager.Rss,
 	}
 }
@@ -37,28 +41,50 @@ func (fc *ArticleController) GetArticle(w http.ResponseWriter, r *http.Request)
 	httpx.WriteJSON(w, http.StatusOK, article)
 }
 
+// ArticleListResponse is a page of articles with counts of all matching
+// articles by category and by source
+type ArticleListResponse struct {
+	utils.PaginatedResponse
+	Facets *models.ArticleFacets `json:"facets"`
+}
+
 func (fc *ArticleController) GetAllArticles(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	// Get pagination parameters from query string
 	pageParam := r.URL.Query().Get("page")
 	pageSizeParam := r.URL.Query().Get("pageSize")
-	searchParam := r.URL.Query().Get("search")
+	query, err := models.ParseArticleQuery(r.URL.Query())
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Parse pagination parameters
 	paginationParams := utils.GetPaginationParams(pageParam, pageSizeParam)
 
-	// Get paginated articles with search
-	articles, total, err := fc.articleService.GetAllArticlesPaginatedAndSearch(
+	if query.Keyset {
+		fc.getArticlesAfter(w, query, paginationParams.PageSize)
+		return
+	}
+
+	// Get paginated articles matching the query
+	articles, total, err := fc.articleService.GetArticlesByQuery(
 		paginationParams.PageSize,
 		paginationParams.CalculateOffset(),
-		searchParam,
+		query,
 	)
 	if err != nil {
 		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	facets, err := fc.articleService.GetArticleFacets(query)
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	// Create paginated response
 	pagination := utils.CreatePaginationResult(
 		paginationParams.Page,
@@ -66,14 +92,71 @@ func (fc *ArticleController) GetAllArticles(w http.ResponseWriter, r *http.Reque
 		total,
 	)
 
-	response := utils.PaginatedResponse{
-		Data:       articles,
-		Pagination: pagination,
+	response := ArticleListResponse{
+		PaginatedResponse: utils.PaginatedResponse{
+			Data:       query.FieldSet.Views(articles),
+			Pagination: pagination,
+		},
+		Facets: facets,
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, response)
+}
+
+// ArticleCursorResponse is a keyset-paginated page of articles. Facets are
+// only counted for the first page, since they are the same for every page.
+type ArticleCursorResponse struct {
+	utils.CursorPaginatedResponse
+	Facets *models.ArticleFacets `json:"facets,omitempty"`
+}
+
+func (fc *ArticleController) getArticlesAfter(w http.ResponseWriter, query models.ArticleQuery, pageSize int) {
+	articles, next, err := fc.articleService.GetArticlesAfter(pageSize, query)
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := ArticleCursorResponse{
+		CursorPaginatedResponse: utils.CursorPaginatedResponse{
+			Data:       query.FieldSet.Views(articles),
+			Pagination: utils.CreateCursorPaginationResult(pageSize, next),
+		},
+	}
+	if query.After == nil {
+		if response.Facets, err = fc.articleService.GetArticleFacets(query); err != nil {
+			httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
 
 	httpx.WriteJSON(w, http.StatusOK, response)
 }
 
+func (fc *ArticleController) GetRelatedArticles(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	articles, err := fc.clusterService.GetRelatedArticles(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, articles)
+}
+
+func (fc *ArticleController) GetArticleRevisions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	revisions, err := fc.articleService.GetArticleRevisions(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, revisions)
+}
+
 func (fc *ArticleController) UpdateArticle(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	_, err := fc.articleService.GetArticleByID(vars["id"])
@@ -88,6 +171,10 @@ func (fc *ArticleController) UpdateArticle(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	updatedArticle, err := fc.articleService.UpdateArticle(vars["id"], updates)
+	if errors.Is(err, services.ErrUnknownRegion) {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -130,5 +217,5 @@ func (fc *ArticleController) CreateFromRssFeed(w http.ResponseWriter, r *http.Re
 		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	fc.rssService.IngestRSSFeed(body.Url)
+	fc.rssService.IngestRSSFeed(r.Context(), body.Url)
 }
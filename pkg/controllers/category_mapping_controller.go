@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vuka-api/pkg/config"
+	"vuka-api/pkg/httpx"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/services"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// CategoryMappingController manages the rules that group feed categories.
+type CategoryMappingController struct {
+	service *services.CategoryMappingService
+}
+
+// NewCategoryMappingController creates a new CategoryMappingController.
+func NewCategoryMappingController() *CategoryMappingController {
+	serviceManager := services.NewServices(config.GetDB())
+	return &CategoryMappingController{service: serviceManager.Mapping}
+}
+
+func (c *CategoryMappingController) GetGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := c.service.GetGroups()
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, groups)
+}
+
+func (c *CategoryMappingController) GetGroupByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	group, err := c.service.GetGroupByID(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, group)
+}
+
+func (c *CategoryMappingController) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var group db.CategoryGroup
+	if err := httpx.ParseBody(r, &group); err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.service.CreateGroup(&group); err != nil {
+		writeMappingError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, group)
+}
+
+func (c *CategoryMappingController) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var group db.CategoryGroup
+	if err := httpx.ParseBody(r, &group); err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, "Invalid category group ID", http.StatusBadRequest)
+		return
+	}
+	group.ID = id
+	if err := c.service.UpdateGroup(&group); err != nil {
+		writeMappingError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, group)
+}
+
+func (c *CategoryMappingController) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := c.service.DeleteGroup(vars["id"]); err != nil {
+		writeMappingError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CategoryMappingController) AddKeyword(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var keyword db.CategoryKeyword
+	if err := httpx.ParseBody(r, &keyword); err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.service.AddKeyword(vars["id"], &keyword); err != nil {
+		writeMappingError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, keyword)
+}
+
+func (c *CategoryMappingController) DeleteKeyword(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := c.service.DeleteKeyword(vars["id"], vars["keywordId"]); err != nil {
+		writeMappingError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Reload forces the mapping rules to be read from the database again
+func (c *CategoryMappingController) Reload(w http.ResponseWriter, r *http.Request) {
+	if err := c.service.Reload(); err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{"message": "Category mapping reloaded"})
+}
+
+func writeMappingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCategoryGroup), errors.Is(err, services.ErrInvalidKeywordWeight):
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httpx.WriteErrorJSON(w, "Category group not found", http.StatusNotFound)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		httpx.WriteErrorJSON(w, "Category group or keyword already exists", http.StatusConflict)
+	default:
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+	}
+}
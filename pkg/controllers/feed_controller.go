@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"vuka-api/pkg/config"
+	"vuka-api/pkg/httpx"
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/services"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// FeedController serves Vuka's curated articles as RSS 2.0 and Atom feeds.
+type FeedController struct {
+	syndicationService *services.SyndicationService
+}
+
+// NewFeedController creates a new FeedController.
+func NewFeedController() *FeedController {
+	serviceManager := services.NewServices(config.GetDB())
+	return &FeedController{syndicationService: serviceManager.Syndication}
+}
+
+func (fc *FeedController) GetLatestFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := fc.syndicationService.LatestFeed()
+	fc.writeFeed(w, r, feed, err)
+}
+
+func (fc *FeedController) GetFeaturedFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := fc.syndicationService.FeaturedFeed()
+	fc.writeFeed(w, r, feed, err)
+}
+
+func (fc *FeedController) GetCategoryFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if _, err := uuid.Parse(vars["id"]); err != nil {
+		httpx.WriteErrorJSON(w, "Category not found", http.StatusNotFound)
+		return
+	}
+	feed, err := fc.syndicationService.CategoryFeed(vars["id"])
+	fc.writeFeed(w, r, feed, err)
+}
+
+func (fc *FeedController) GetRegionFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	feed, err := fc.syndicationService.RegionFeed(vars["slug"])
+	fc.writeFeed(w, r, feed, err)
+}
+
+// writeFeed renders the feed as Atom when the path ends in .atom or
+// format=atom is given, and as RSS otherwise. The ETag is a hash of the
+// document, so unchanged feeds are answered with 304 Not Modified.
+func (fc *FeedController) writeFeed(w http.ResponseWriter, r *http.Request, feed *models.OutputFeed, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		httpx.WriteErrorJSON(w, "Feed not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Responses are cached publicly, so links never come from request headers
+	baseURL := strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/")
+	if baseURL == "" {
+		log.Printf("PUBLIC_BASE_URL is not set, refusing to serve %s", r.URL.Path)
+		httpx.WriteErrorJSON(w, "Feeds are not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	// The self link names the document actually served, whatever the query
+	atom := strings.HasSuffix(r.URL.Path, ".atom") || r.URL.Query().Get("format") == "atom"
+	selfPath := strings.TrimSuffix(strings.TrimSuffix(r.URL.Path, ".xml"), ".atom")
+	if atom {
+		selfPath += ".atom"
+	} else {
+		selfPath += ".xml"
+	}
+	feed.BaseURL = baseURL
+	feed.SelfURL = baseURL + selfPath
+
+	var body []byte
+	if atom {
+		body, err = feed.Atom()
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	} else {
+		body, err = feed.RSS()
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	}
+	if err != nil {
+		w.Header().Del("Content-Type")
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Feeds change at most once per ingestion run
+	sum := sha256.Sum256(body)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Cache-Control", "public, max-age=600")
+	http.ServeContent(w, r, "", feed.Updated(), bytes.NewReader(body))
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"vuka-api/pkg/config"
+	"vuka-api/pkg/httpx"
+	"vuka-api/pkg/services"
+	"vuka-api/pkg/utils"
+
+	"github.com/gorilla/mux"
+)
+
+type IngestionController struct {
+	ingestionService *services.IngestionService
+}
+
+func NewIngestionController() *IngestionController {
+	serviceManager := services.NewServices(config.GetDB())
+	return &IngestionController{
+		ingestionService: serviceManager.Ingestion,
+	}
+}
+
+func (ic *IngestionController) GetRuns(w http.ResponseWriter, r *http.Request) {
+	paginationParams := utils.GetPaginationParams(r.URL.Query().Get("page"), r.URL.Query().Get("pageSize"))
+
+	runs, total, err := ic.ingestionService.GetRuns(
+		paginationParams.PageSize,
+		paginationParams.CalculateOffset(),
+	)
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := utils.PaginatedResponse{
+		Data:       runs,
+		Pagination: utils.CreatePaginationResult(paginationParams.Page, paginationParams.PageSize, total),
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, response)
+}
+
+func (ic *IngestionController) GetRunByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	run, err := ic.ingestionService.GetRunByID(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, run)
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"vuka-api/pkg/config"
+	"vuka-api/pkg/httpx"
+	"vuka-api/pkg/services"
+
+	"github.com/gorilla/mux"
+)
+
+type MediaController struct {
+	imageService *services.ImageService
+}
+
+func NewMediaController() *MediaController {
+	serviceManager := services.NewServices(config.GetDB())
+	return &MediaController{
+		imageService: serviceManager.Image,
+	}
+}
+
+// GetMedia serves an article image from media storage. Pass size=thumb for
+// the thumbnail. Images that have not been stored redirect to the original URL.
+func (mc *MediaController) GetMedia(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	thumbnail := r.URL.Query().Get("size") == "thumb"
+
+	file, originalURL, err := mc.imageService.OpenMedia(r.Context(), vars["id"], thumbnail)
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if file == nil {
+		// The image may be stored later, so the redirect is only briefly cacheable
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		http.Redirect(w, r, originalURL, http.StatusFound)
+		return
+	}
+	defer file.Body.Close()
+
+	// Stored objects never change under the same key
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", file.ETag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if seeker, ok := file.Body.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", file.ModTime, seeker)
+		return
+	}
+	if match := r.Header.Get("If-None-Match"); match == file.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	io.Copy(w, file.Body)
+}
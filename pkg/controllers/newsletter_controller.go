@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"vuka-api/pkg/config"
 	"vuka-api/pkg/httpx"
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
 	"vuka-api/pkg/services"
+	"vuka-api/pkg/utils"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
@@ -43,7 +45,14 @@ func (nc *NewsletterController) Subscribe(w http.ResponseWriter, r *http.Request
 	httpx.WriteJSON(w, http.StatusCreated, subscriber)
 }
 
-func (nc *NewsletterController) GetAllSubscribers(w http.ResponseWriter, _ *http.Request) {
+// GetAllSubscribers lists every subscriber, or a page of them, newest first,
+// when a cursor parameter is given (empty for the first page)
+func (nc *NewsletterController) GetAllSubscribers(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("cursor") {
+		nc.getSubscribersAfter(w, r)
+		return
+	}
+
 	subscribers, err := nc.newsletterService.GetAllSubscribers()
 	if err != nil {
 		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
@@ -53,6 +62,30 @@ func (nc *NewsletterController) GetAllSubscribers(w http.ResponseWriter, _ *http
 	httpx.WriteJSON(w, http.StatusOK, subscribers)
 }
 
+func (nc *NewsletterController) getSubscribersAfter(w http.ResponseWriter, r *http.Request) {
+	var after *models.Cursor
+	if token := r.URL.Query().Get("cursor"); token != "" {
+		cursor, err := models.DecodeCursor(token, "createdAt")
+		if err != nil {
+			httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		after = cursor
+	}
+	pageSize := utils.GetPaginationParams("", r.URL.Query().Get("pageSize")).PageSize
+
+	subscribers, next, err := nc.newsletterService.GetSubscribersAfter(after, pageSize)
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, utils.CursorPaginatedResponse{
+		Data:       subscribers,
+		Pagination: utils.CreateCursorPaginationResult(pageSize, next),
+	})
+}
+
 func (nc *NewsletterController) GetSubscriberByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vuka-api/pkg/config"
+	"vuka-api/pkg/httpx"
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/services"
+	"vuka-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// RegionController manages regions and lists the articles filed under them.
+type RegionController struct {
+	regionService *services.RegionService
+}
+
+// NewRegionController creates a new RegionController.
+func NewRegionController() *RegionController {
+	serviceManager := services.NewServices(config.GetDB())
+	return &RegionController{regionService: serviceManager.Region}
+}
+
+func (rc *RegionController) GetAllRegions(w http.ResponseWriter, r *http.Request) {
+	regions, err := rc.regionService.GetAllRegions()
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, regions)
+}
+
+func (rc *RegionController) GetRegionBySlug(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	region, err := rc.regionService.GetRegionBySlug(vars["slug"])
+	if err != nil {
+		writeRegionError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, region)
+}
+
+func (rc *RegionController) CreateRegion(w http.ResponseWriter, r *http.Request) {
+	var region db.Region
+	if err := httpx.ParseBody(r, &region); err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := rc.regionService.CreateRegion(&region); err != nil {
+		writeRegionError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, region)
+}
+
+func (rc *RegionController) UpdateRegion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var region db.Region
+	if err := httpx.ParseBody(r, &region); err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, "Invalid region ID", http.StatusBadRequest)
+		return
+	}
+	region.ID = id
+	if err := rc.regionService.UpdateRegion(&region); err != nil {
+		writeRegionError(w, err)
+		return
+	}
+	updated, err := rc.regionService.GetRegionByID(id.String())
+	if err != nil {
+		writeRegionError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, updated)
+}
+
+func (rc *RegionController) DeleteRegion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := rc.regionService.DeleteRegion(vars["id"]); err != nil {
+		writeRegionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetRegionArticles lists the articles filed under a region, newest first
+func (rc *RegionController) GetRegionArticles(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	paginationParams := utils.GetPaginationParams(r.URL.Query().Get("page"), r.URL.Query().Get("pageSize"))
+	query, err := models.ParseArticleQuery(r.URL.Query())
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if query.Keyset {
+		articles, next, err := rc.regionService.GetRegionArticlesAfter(vars["slug"], paginationParams.PageSize, query)
+		if err != nil {
+			writeRegionError(w, err)
+			return
+		}
+		httpx.WriteJSON(w, http.StatusOK, utils.CursorPaginatedResponse{
+			Data:       query.FieldSet.Views(articles),
+			Pagination: utils.CreateCursorPaginationResult(paginationParams.PageSize, next),
+		})
+		return
+	}
+
+	articles, total, err := rc.regionService.GetRegionArticles(
+		vars["slug"],
+		paginationParams.PageSize,
+		paginationParams.CalculateOffset(),
+		query,
+	)
+	if err != nil {
+		writeRegionError(w, err)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, utils.PaginatedResponse{
+		Data:       query.FieldSet.Views(articles),
+		Pagination: utils.CreatePaginationResult(paginationParams.Page, paginationParams.PageSize, total),
+	})
+}
+
+func writeRegionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRegion):
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httpx.WriteErrorJSON(w, "Region not found", http.StatusNotFound)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		httpx.WriteErrorJSON(w, "A region with this slug already exists", http.StatusConflict)
+	default:
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+	}
+}
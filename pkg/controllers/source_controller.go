@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"vuka-api/pkg/config"
@@ -10,18 +12,19 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 )
 
 type SourceController struct {
-	sourceService *services.SourceService
-	rssService    *services.RssService
+	sourceService    *services.SourceService
+	ingestionService *services.IngestionService
 }
 
 func NewSourceController() *SourceController {
 	serviceManager := services.NewServices(config.GetDB())
 	return &SourceController{
-		sourceService: serviceManager.Source,
-		rssService:    serviceManager.Rss,
+		sourceService:    serviceManager.Source,
+		ingestionService: serviceManager.Ingestion,
 	}
 }
 
@@ -51,7 +54,7 @@ func (sc *SourceController) CreateSource(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if err := sc.sourceService.CreateSource(&source); err != nil {
-		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		writeSourceError(w, err)
 		return
 	}
 	httpx.WriteJSON(w, http.StatusCreated, source)
@@ -71,11 +74,12 @@ func (sc *SourceController) UpdateSource(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	source.ID = id
-	if err := sc.sourceService.UpdateSource(&source); err != nil {
-		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+	updated, err := sc.sourceService.UpdateSource(&source)
+	if err != nil {
+		writeSourceError(w, err)
 		return
 	}
-	httpx.WriteJSON(w, http.StatusOK, source)
+	httpx.WriteJSON(w, http.StatusOK, updated)
 }
 
 func (sc *SourceController) DeleteSource(w http.ResponseWriter, r *http.Request) {
@@ -108,11 +112,49 @@ func (sc *SourceController) IngestSourceFeed(w http.ResponseWriter, r *http.Requ
 	}
 
 	go func() {
-		if err := sc.rssService.IngestRSSFeedWithSource(source.RssFeedUrl, &sourceID); err != nil {
+		result := sc.ingestionService.IngestSource(context.Background(), *source)
+		if result.Error != "" {
 			// Log the error, but don't write to the response as it's in a goroutine
-			fmt.Printf("Error ingesting RSS feed for source %s: %v\n", sourceID, err)
+			fmt.Printf("Error ingesting RSS feed for source %s: %s\n", sourceID, result.Error)
 		}
 	}()
 
 	httpx.WriteJSON(w, http.StatusAccepted, map[string]string{"message": "RSS feed ingestion started"})
 }
+
+func (sc *SourceController) EnableSource(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if _, err := sc.sourceService.GetSourceByID(vars["id"]); err != nil {
+		httpx.WriteErrorJSON(w, "Source not found", http.StatusNotFound)
+		return
+	}
+	source, err := sc.sourceService.EnableSource(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, source)
+}
+
+func (sc *SourceController) GetSourceHealth(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	health, err := sc.ingestionService.GetSourceHealth(vars["id"])
+	if err != nil {
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, health)
+}
+
+// writeSourceError maps invalid source defaults to 400, a missing source to
+// 404 and anything else to 500
+func writeSourceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnknownCategoryGroup), errors.Is(err, services.ErrUnknownRegion):
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httpx.WriteErrorJSON(w, "Source not found", http.StatusNotFound)
+	default:
+		httpx.WriteErrorJSON(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -3,7 +3,10 @@ package main
 import (
 	"fmt"
 	"vuka-api/pkg/config"
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
+
+	"gorm.io/gorm"
 )
 
 func init() {
@@ -20,8 +23,10 @@ func main() {
 		&db.Source{},
 		&db.Section{},
 		&db.Category{},
+		&db.StoryCluster{},
 		&db.Article{},
 		&db.ArticleImage{},
+		&db.ArticleRevision{},
 		&db.Region{},
 		&db.DirectoryCategory{},
 		&db.DirectoryEntry{},
@@ -32,10 +37,196 @@ func main() {
 		&db.RoleSectionPermission{},
 		&db.UserDirectoryMeta{},
 		&db.NewsletterSubscriber{},
+		&db.IngestionRun{},
+		&db.IngestionAttempt{},
+		&db.CategoryGroup{},
+		&db.CategoryKeyword{},
+		&db.ClassifierModel{},
 	)
 	if err != nil {
 		fmt.Printf("Migration failed: %v\n", err)
 		return
 	}
+
+	if err := createSearchIndex(config.GetDB()); err != nil {
+		fmt.Printf("Creating the article search index failed: %v\n", err)
+		return
+	}
+
+	if err := createKeysetIndexes(config.GetDB()); err != nil {
+		fmt.Printf("Creating the pagination indexes failed: %v\n", err)
+		return
+	}
+
+	if err := sanitizeStoredArticles(config.GetDB()); err != nil {
+		fmt.Printf("Sanitizing stored articles failed: %v\n", err)
+		return
+	}
+
+	if err := detectStoredLanguages(config.GetDB()); err != nil {
+		fmt.Printf("Detecting article languages failed: %v\n", err)
+		return
+	}
+
+	if err := seedCategoryGroups(config.GetDB()); err != nil {
+		fmt.Printf("Seeding category groups failed: %v\n", err)
+		return
+	}
+
+	if err := seedRegions(config.GetDB()); err != nil {
+		fmt.Printf("Seeding regions failed: %v\n", err)
+		return
+	}
 	fmt.Println("Migration completed successfully!")
 }
+
+// createSearchIndex adds the generated tsvector column articles are searched
+// by and its GIN index. The column is kept up to date by Postgres, including
+// for articles stored before it existed.
+func createSearchIndex(database *gorm.DB) error {
+	err := database.Exec("ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector tsvector " +
+		"GENERATED ALWAYS AS (" + models.SearchVectorSQL() + ") STORED").Error
+	if err != nil {
+		return err
+	}
+	return database.Exec("CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector)").Error
+}
+
+// createKeysetIndexes adds the (time, id) indexes cursor pagination walks
+func createKeysetIndexes(database *gorm.DB) error {
+	statements := []string{
+		"CREATE INDEX IF NOT EXISTS idx_articles_published_at_id ON articles (published_at, id)",
+		"CREATE INDEX IF NOT EXISTS idx_articles_created_at_id ON articles (created_at, id)",
+		"CREATE INDEX IF NOT EXISTS idx_newsletter_subscribers_created_at_id ON newsletter_subscribers (created_at, id)",
+	}
+	for _, statement := range statements {
+		if err := database.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sanitizeStoredArticles runs articles ingested before HTML sanitization
+// through the sanitizer and fills in their plain-text fields. Articles with a
+// blank summary are included, since their content may still carry scripts.
+func sanitizeStoredArticles(database *gorm.DB) error {
+	var articles []db.Article
+	sanitized := 0
+	result := database.Select("id", "summary", "content_body").
+		Where("summary_text = '' AND content_text = ''").
+		Where("summary <> '' OR content_body <> ''").
+		FindInBatches(&articles, 200, func(tx *gorm.DB, batch int) error {
+			for _, article := range articles {
+				summary := models.SanitizeSummaryHTML(article.Summary)
+				content := models.SanitizeHTML(article.ContentBody)
+				err := tx.Model(&db.Article{}).Where("id = ?", article.ID).Updates(map[string]any{
+					"summary":      summary,
+					"summary_text": models.HTMLToText(summary),
+					"content_body": content,
+					"content_text": models.HTMLToText(content),
+				}).Error
+				if err != nil {
+					return err
+				}
+				sanitized++
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	fmt.Printf("Sanitized %d stored articles\n", sanitized)
+	return nil
+}
+
+// detectStoredLanguages guesses the language of articles ingested before
+// language detection from their title and summary
+func detectStoredLanguages(database *gorm.DB) error {
+	var articles []db.Article
+	detected := 0
+	result := database.Select("id", "title", "summary_text").
+		Where("detected_language = ''").
+		FindInBatches(&articles, 200, func(tx *gorm.DB, batch int) error {
+			for _, article := range articles {
+				language, confidence := models.DetectLanguage(article.Title + "\n" + article.SummaryText)
+				if language == "" {
+					continue
+				}
+				err := tx.Model(&db.Article{}).Where("id = ?", article.ID).Updates(map[string]any{
+					"detected_language":   language,
+					"language_confidence": confidence,
+				}).Error
+				if err != nil {
+					return err
+				}
+				detected++
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	fmt.Printf("Detected the language of %d stored articles\n", detected)
+	return nil
+}
+
+// seedCategoryGroups stores the built-in category groups the first time the
+// mapping rules are migrated, so editors start from the previous behaviour
+func seedCategoryGroups(database *gorm.DB) error {
+	var count int64
+	if err := database.Model(&db.CategoryGroup{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	for _, group := range models.DefaultCategoryGroups() {
+		record := db.CategoryGroup{Name: group.Name}
+		for _, keyword := range group.Keywords {
+			record.Keywords = append(record.Keywords, db.CategoryKeyword{Keyword: keyword, Weight: 1})
+		}
+		for _, keyword := range group.Weighted {
+			record.Keywords = append(record.Keywords, db.CategoryKeyword{
+				Keyword:  keyword.Keyword,
+				Weight:   keyword.Weight,
+				Negative: keyword.Negative,
+			})
+		}
+		if err := database.Create(&record).Error; err != nil {
+			return err
+		}
+	}
+	fmt.Println("Seeded default category groups")
+	return nil
+}
+
+// provinces are the regions seeded into an empty regions table
+var provinces = []string{
+	"Eastern Cape", "Free State", "Gauteng", "KwaZulu-Natal", "Limpopo",
+	"Mpumalanga", "North West", "Northern Cape", "Western Cape",
+}
+
+// seedRegions stores the South African provinces the first time regions are
+// migrated; editors can rename or add regions afterwards
+func seedRegions(database *gorm.DB) error {
+	var count int64
+	if err := database.Unscoped().Model(&db.Region{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	for _, name := range provinces {
+		region := db.Region{Name: name, Slug: models.Slugify(name)}
+		if err := database.Create(&region).Error; err != nil {
+			return err
+		}
+	}
+	fmt.Println("Seeded South African provinces as regions")
+	return nil
+}
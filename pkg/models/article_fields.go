@@ -0,0 +1,204 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"vuka-api/pkg/models/db"
+)
+
+// articleField is a field clients can ask for in an article listing
+type articleField struct {
+	name   string // JSON name, as in db.Article
+	column string // Column it is loaded from; empty for values computed by a search
+	value  func(article *db.Article) any
+}
+
+var articleFields = []articleField{
+	{"id", "id", func(a *db.Article) any { return a.ID }},
+	{"createdAt", "created_at", func(a *db.Article) any { return a.CreatedAt }},
+	{"updatedAt", "updated_at", func(a *db.Article) any { return a.UpdatedAt }},
+	{"title", "title", func(a *db.Article) any { return a.Title }},
+	{"language", "language", func(a *db.Article) any { return a.Language }},
+	{"detectedLanguage", "detected_language", func(a *db.Article) any { return a.DetectedLanguage }},
+	{"languageConfidence", "language_confidence", func(a *db.Article) any { return a.LanguageConfidence }},
+	{"originalUrl", "original_url", func(a *db.Article) any { return a.OriginalUrl }},
+	{"canonicalUrl", "canonical_url", func(a *db.Article) any { return a.CanonicalUrl }},
+	{"guid", "guid", func(a *db.Article) any { return a.GUID }},
+	{"summary", "summary", func(a *db.Article) any { return a.Summary }},
+	{"summaryText", "summary_text", func(a *db.Article) any { return a.SummaryText }},
+	{"contentBody", "content_body", func(a *db.Article) any { return a.ContentBody }},
+	{"contentText", "content_text", func(a *db.Article) any { return a.ContentText }},
+	{"contentExtractedAt", "content_extracted_at", func(a *db.Article) any { return a.ContentExtractedAt }},
+	{"publishedAt", "published_at", func(a *db.Article) any { return a.PublishedAt }},
+	{"publishedAtEstimated", "published_at_estimated", func(a *db.Article) any { return a.PublishedAtEstimated }},
+	{"isFeatured", "is_featured", func(a *db.Article) any { return a.IsFeatured }},
+	{"sourceId", "source_id", func(a *db.Article) any { return a.SourceID }},
+	{"regionID", "region_id", func(a *db.Article) any { return a.RegionID }},
+	{"categoriesInferred", "categories_inferred", func(a *db.Article) any { return a.CategoriesInferred }},
+	{"categoryDefaulted", "category_defaulted", func(a *db.Article) any { return a.CategoryDefaulted }},
+	{"revisionCount", "revision_count", func(a *db.Article) any { return a.RevisionCount }},
+	{"revisedAt", "revised_at", func(a *db.Article) any { return a.RevisedAt }},
+	{"clusterId", "cluster_id", func(a *db.Article) any { return a.ClusterID }},
+	{"searchRank", "", func(a *db.Article) any { return a.SearchRank }},
+	{"headline", "", func(a *db.Article) any { return a.SearchHeadline }},
+}
+
+// articleRelation is a relation clients can ask to be loaded with each article
+type articleRelation struct {
+	name       string // JSON name, as in db.Article
+	preload    string // Association preloaded by the repository
+	foreignKey string // Column the association needs, if it is on the article
+	value      func(article *db.Article) any
+}
+
+var articleRelations = []articleRelation{
+	{"source", "Source", "source_id", func(a *db.Article) any { return a.Source }},
+	{"region", "Region", "region_id", func(a *db.Article) any { return a.Region }},
+	{"images", "Images", "", func(a *db.Article) any { return a.Images }},
+	{"categories", "Categories", "", func(a *db.Article) any { return a.Categories }},
+	{"cluster", "Cluster", "cluster_id", func(a *db.Article) any { return a.Cluster }},
+}
+
+// DefaultArticleFields are returned by listings that do not ask for fields;
+// enough to render a list without the article bodies
+var DefaultArticleFields = []string{
+	"id", "title", "summaryText", "originalUrl", "language", "publishedAt",
+	"isFeatured", "sourceId", "regionID", "clusterId", "searchRank", "headline",
+}
+
+// DefaultArticleIncludes are the relations loaded when a listing does not ask for any
+var DefaultArticleIncludes = []string{"source", "images", "categories"}
+
+// ArticleFieldSet is the sparse fieldset of an article listing: which fields
+// are returned and which relations are loaded with them
+type ArticleFieldSet struct {
+	fields    []articleField
+	relations []articleRelation
+}
+
+// ParseArticleFieldSet reads the fields and include query parameters, comma
+// separated lists of the JSON names of article fields and relations.
+// "fields=*" returns every field and an empty include loads no relations;
+// either parameter left out falls back to the lightweight defaults.
+func ParseArticleFieldSet(values url.Values) (*ArticleFieldSet, error) {
+	fieldNames := DefaultArticleFields
+	if values.Has("fields") {
+		fieldNames = splitList(values.Get("fields"))
+	}
+	includeNames := DefaultArticleIncludes
+	if values.Has("include") {
+		includeNames = splitList(values.Get("include"))
+	}
+
+	return NewArticleFieldSet(fieldNames, includeNames)
+}
+
+// NewArticleFieldSet builds a fieldset from the JSON names of article fields
+// and relations; "*" selects every field
+func NewArticleFieldSet(fieldNames, includeNames []string) (*ArticleFieldSet, error) {
+	fieldSet := &ArticleFieldSet{}
+	for _, name := range fieldNames {
+		if name == "*" {
+			fieldSet.fields = articleFields
+			break
+		}
+		field, ok := findArticleField(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown article field %q", name)
+		}
+		fieldSet.fields = append(fieldSet.fields, field)
+	}
+	for _, name := range includeNames {
+		relation, ok := findArticleRelation(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown article relation %q, expected source, region, images, categories or cluster", name)
+		}
+		fieldSet.relations = append(fieldSet.relations, relation)
+	}
+	return fieldSet, nil
+}
+
+// Columns are the article columns the repository has to load: the requested
+// fields, the keys of included relations, and the ID and timestamps that
+// sorting and cursors rely on
+func (fs *ArticleFieldSet) Columns() []string {
+	columns := []string{"id", "created_at", "published_at"}
+	seen := map[string]bool{"id": true, "created_at": true, "published_at": true}
+	add := func(column string) {
+		if column != "" && !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	for _, field := range fs.fields {
+		add(field.column)
+	}
+	for _, relation := range fs.relations {
+		add(relation.foreignKey)
+	}
+	return columns
+}
+
+// Preloads are the associations the repository has to load
+func (fs *ArticleFieldSet) Preloads() []string {
+	preloads := make([]string, len(fs.relations))
+	for i, relation := range fs.relations {
+		preloads[i] = relation.preload
+	}
+	return preloads
+}
+
+// View returns the requested fields and relations of an article. Search
+// fields are left out of articles that were not found by a search.
+func (fs *ArticleFieldSet) View(article *db.Article) map[string]any {
+	view := make(map[string]any, len(fs.fields)+len(fs.relations))
+	for _, field := range fs.fields {
+		if field.column == "" && article.SearchHeadline == "" && article.SearchRank == 0 {
+			continue
+		}
+		view[field.name] = field.value(article)
+	}
+	for _, relation := range fs.relations {
+		view[relation.name] = relation.value(article)
+	}
+	return view
+}
+
+// Views applies View to every article
+func (fs *ArticleFieldSet) Views(articles []db.Article) []map[string]any {
+	views := make([]map[string]any, len(articles))
+	for i := range articles {
+		views[i] = fs.View(&articles[i])
+	}
+	return views
+}
+
+func findArticleField(name string) (articleField, bool) {
+	for _, field := range articleFields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	return articleField{}, false
+}
+
+func findArticleRelation(name string) (articleRelation, bool) {
+	for _, relation := range articleRelations {
+		if relation.name == name {
+			return relation, true
+		}
+	}
+	return articleRelation{}, false
+}
+
+// splitList splits a comma separated parameter, dropping blank items
+func splitList(param string) []string {
+	var items []string
+	for _, item := range strings.Split(param, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
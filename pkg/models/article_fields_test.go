@@ -0,0 +1,96 @@
+package models
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+func TestParseArticleFieldSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		columns  []string
+		preloads []string
+		errors   bool
+	}{
+		{
+			name:     "Defaults leave out the article bodies",
+			query:    "",
+			columns:  []string{"id", "created_at", "published_at", "title", "summary_text", "original_url", "language", "is_featured", "source_id", "region_id", "cluster_id"},
+			preloads: []string{"Source", "Images", "Categories"},
+		},
+		{
+			name:     "Requested fields and relation keys",
+			query:    "fields=title,contentBody&include=region",
+			columns:  []string{"id", "created_at", "published_at", "title", "content_body", "region_id"},
+			preloads: []string{"Region"},
+		},
+		{
+			name:     "Empty include loads no relations",
+			query:    "fields=id&include=",
+			columns:  []string{"id", "created_at", "published_at"},
+			preloads: []string{},
+		},
+		{name: "Unknown field", query: "fields=title,body", errors: true},
+		{name: "Unknown relation", query: "include=author", errors: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			fieldSet, err := ParseArticleFieldSet(values)
+			if tt.errors {
+				if err == nil {
+					t.Errorf("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if columns := fieldSet.Columns(); !reflect.DeepEqual(columns, tt.columns) {
+				t.Errorf("Expected columns %v, got %v", tt.columns, columns)
+			}
+			if preloads := fieldSet.Preloads(); !reflect.DeepEqual(preloads, tt.preloads) {
+				t.Errorf("Expected preloads %v, got %v", tt.preloads, preloads)
+			}
+		})
+	}
+}
+
+func TestArticleFieldSet_View(t *testing.T) {
+	values, _ := url.ParseQuery("fields=id,title,headline&include=source")
+	fieldSet, err := ParseArticleFieldSet(values)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	article := db.Article{Title: "Eskom suspends load shedding", Source: db.Source{Name: "News24"}}
+	article.ID = uuid.New()
+
+	view := fieldSet.View(&article)
+	if len(view) != 3 || view["title"] != article.Title || view["id"] != article.ID {
+		t.Errorf("Expected id, title and source, got %v", view)
+	}
+	if _, ok := view["headline"]; ok {
+		t.Errorf("Expected no headline outside a search, got %v", view["headline"])
+	}
+	if source, ok := view["source"].(db.Source); !ok || source.Name != "News24" {
+		t.Errorf("Expected the source, got %v", view["source"])
+	}
+
+	article.SearchHeadline = "Eskom suspends <mark>load</mark> shedding"
+	if view := fieldSet.View(&article); view["headline"] != article.SearchHeadline {
+		t.Errorf("Expected the headline of a search result, got %v", view["headline"])
+	}
+
+	values, _ = url.ParseQuery("fields=*")
+	all, _ := ParseArticleFieldSet(values)
+	if view := all.View(&article); len(view) != len(articleFields)+len(DefaultArticleIncludes) {
+		t.Errorf("Expected every field, got %d", len(view))
+	}
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleSort is the order articles are listed in
+type ArticleSort string
+
+const (
+	SortCreatedAt   ArticleSort = "createdAt"   // Newest stored first; the default
+	SortPublishedAt ArticleSort = "publishedAt" // Newest published first
+	SortRelevance   ArticleSort = "relevance"   // Best search match first; needs a search
+)
+
+// ArticleQuery describes how a list of articles should be filtered
+type ArticleQuery struct {
+	Search           string // Matched against article titles and source names
+	CollapseClusters bool   // Only return the representative article of each story cluster
+	Language         string // ISO 639-1 code; the detected language is preferred over the feed's
+	RegionID         string // Only return articles filed under this region
+	RegionSlug       string // Only return articles filed under the region with this slug
+	CategoryIDs      []string
+	SourceIDs        []string
+	Featured         *bool
+	PublishedFrom    *time.Time
+	PublishedTo      *time.Time // Exclusive
+	Sort             ArticleSort
+	Ascending        bool
+	Keyset           bool             // Paginate with cursors instead of page numbers
+	After            *Cursor          // Continue after this article; nil for the first page
+	FieldSet         *ArticleFieldSet // Columns and relations to load; nil loads everything
+}
+
+// ParseArticleQuery reads article filters from query parameters:
+//
+//	search, language, collapse=cluster
+//	category, source  IDs, comma separated or repeated
+//	region            region ID or slug
+//	featured          true or false
+//	from, to          RFC 3339 times or dates; a date in "to" includes that whole day
+//	sort              createdAt, publishedAt or relevance
+//	order             asc or desc, desc by default
+//	cursor            switches to keyset pagination; empty for the first page,
+//	                  then the nextCursor of the previous page. Sorted by
+//	                  publishedAt unless createdAt is asked for.
+//	fields, include   see ParseArticleFieldSet
+func ParseArticleQuery(values url.Values) (ArticleQuery, error) {
+	query := ArticleQuery{
+		Search:           strings.TrimSpace(values.Get("search")),
+		CollapseClusters: values.Get("collapse") == "cluster",
+		Language:         strings.TrimSpace(values.Get("language")),
+	}
+
+	var err error
+	if query.FieldSet, err = ParseArticleFieldSet(values); err != nil {
+		return query, err
+	}
+	if query.CategoryIDs, err = parseIDList(values["category"], "category"); err != nil {
+		return query, err
+	}
+	if query.SourceIDs, err = parseIDList(values["source"], "source"); err != nil {
+		return query, err
+	}
+
+	if region := strings.TrimSpace(values.Get("region")); region != "" {
+		if _, err := uuid.Parse(region); err == nil {
+			query.RegionID = region
+		} else {
+			query.RegionSlug = strings.ToLower(region)
+		}
+	}
+
+	if featured := values.Get("featured"); featured != "" {
+		value, err := strconv.ParseBool(featured)
+		if err != nil {
+			return query, fmt.Errorf("invalid featured value %q", featured)
+		}
+		query.Featured = &value
+	}
+
+	if from := values.Get("from"); from != "" {
+		t, _, err := parseQueryTime(from)
+		if err != nil {
+			return query, fmt.Errorf("invalid from date %q", from)
+		}
+		query.PublishedFrom = &t
+	}
+	if to := values.Get("to"); to != "" {
+		t, dateOnly, err := parseQueryTime(to)
+		if err != nil {
+			return query, fmt.Errorf("invalid to date %q", to)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		query.PublishedTo = &t
+	}
+
+	query.Keyset = values.Has("cursor")
+	switch sort := ArticleSort(values.Get("sort")); sort {
+	case "":
+		if query.Keyset {
+			query.Sort = SortPublishedAt
+		} else if query.Search != "" {
+			query.Sort = SortRelevance
+		} else {
+			query.Sort = SortCreatedAt
+		}
+	case SortCreatedAt, SortPublishedAt, SortRelevance:
+		query.Sort = sort
+	default:
+		return query, fmt.Errorf("invalid sort %q, expected createdAt, publishedAt or relevance", sort)
+	}
+
+	switch order := strings.ToLower(values.Get("order")); order {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return query, fmt.Errorf("invalid order %q, expected asc or desc", order)
+	}
+
+	if query.Keyset {
+		if query.Sort == SortRelevance {
+			return query, fmt.Errorf("cursor pagination needs sort=publishedAt or sort=createdAt")
+		}
+		if token := values.Get("cursor"); token != "" {
+			if query.After, err = DecodeCursor(token, string(query.Sort)); err != nil {
+				return query, err
+			}
+		}
+	}
+
+	return query, nil
+}
+
+// parseIDList splits comma separated and repeated ID parameters, rejecting
+// anything that is not a UUID
+func parseIDList(params []string, name string) ([]string, error) {
+	var ids []string
+	for _, param := range params {
+		for _, id := range strings.Split(param, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+			if _, err := uuid.Parse(id); err != nil {
+				return nil, fmt.Errorf("invalid %s ID %q", name, id)
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// parseQueryTime parses an RFC 3339 time or a date, reporting which it was
+func parseQueryTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+// FacetCount is the number of matching articles sharing a category or source
+type FacetCount struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// ArticleFacets counts the articles matching a query by category and by
+// source. Each facet ignores its own filter, so a client filtering on one
+// category still sees how many articles the other categories would add.
+type ArticleFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Sources    []FacetCount `json:"sources"`
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseArticleQuery(t *testing.T) {
+	category1 := "6f1c7f0e-3f4b-4b43-9a63-2f4bb3f1d9a1"
+	category2 := "0b7c3e5a-8d53-4a53-8a1e-5b9c6f4e2d10"
+	source := "a3b1c2d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+
+	tests := []struct {
+		name   string
+		query  string
+		check  func(t *testing.T, q ArticleQuery)
+		errors bool
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			check: func(t *testing.T, q ArticleQuery) {
+				if q.Sort != SortCreatedAt || q.Ascending || q.Featured != nil {
+					t.Errorf("Expected newest first with no filters, got %+v", q)
+				}
+			},
+		},
+		{
+			name:  "Search sorts by relevance",
+			query: "search=load+shedding",
+			check: func(t *testing.T, q ArticleQuery) {
+				if q.Sort != SortRelevance {
+					t.Errorf("Expected relevance, got %s", q.Sort)
+				}
+			},
+		},
+		{
+			name:  "Comma separated and repeated IDs",
+			query: "category=" + category1 + "," + category2 + "&source=" + source,
+			check: func(t *testing.T, q ArticleQuery) {
+				if len(q.CategoryIDs) != 2 || q.CategoryIDs[1] != category2 {
+					t.Errorf("Expected both categories, got %v", q.CategoryIDs)
+				}
+				if len(q.SourceIDs) != 1 || q.SourceIDs[0] != source {
+					t.Errorf("Expected the source, got %v", q.SourceIDs)
+				}
+			},
+		},
+		{
+			name:  "Region slug",
+			query: "region=Gauteng",
+			check: func(t *testing.T, q ArticleQuery) {
+				if q.RegionSlug != "gauteng" || q.RegionID != "" {
+					t.Errorf("Expected slug gauteng, got %+v", q)
+				}
+			},
+		},
+		{
+			name:  "Region ID",
+			query: "region=" + source,
+			check: func(t *testing.T, q ArticleQuery) {
+				if q.RegionID != source || q.RegionSlug != "" {
+					t.Errorf("Expected region ID, got %+v", q)
+				}
+			},
+		},
+		{
+			name:  "Featured and published range",
+			query: "featured=true&from=2024-03-01&to=2024-03-31&sort=publishedAt&order=asc",
+			check: func(t *testing.T, q ArticleQuery) {
+				if q.Featured == nil || !*q.Featured {
+					t.Errorf("Expected featured, got %v", q.Featured)
+				}
+				if !q.PublishedFrom.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("Expected from 2024-03-01, got %v", q.PublishedFrom)
+				}
+				if !q.PublishedTo.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("Expected a date to include the whole day, got %v", q.PublishedTo)
+				}
+				if q.Sort != SortPublishedAt || !q.Ascending {
+					t.Errorf("Expected oldest published first, got %s ascending=%v", q.Sort, q.Ascending)
+				}
+			},
+		},
+		{
+			name:  "RFC 3339 upper bound is exact",
+			query: "to=2024-03-31T12:00:00%2B02:00",
+			check: func(t *testing.T, q ArticleQuery) {
+				if !q.PublishedTo.Equal(time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC)) {
+					t.Errorf("Expected 10:00 UTC, got %v", q.PublishedTo)
+				}
+			},
+		},
+		{
+			name:  "First cursor page sorts by publication",
+			query: "cursor=&search=eskom",
+			check: func(t *testing.T, q ArticleQuery) {
+				if !q.Keyset || q.After != nil || q.Sort != SortPublishedAt {
+					t.Errorf("Expected the first keyset page by publishedAt, got %+v", q)
+				}
+			},
+		},
+		{
+			name:  "Next cursor page",
+			query: "cursor=" + Cursor{Sort: "createdAt", Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), ID: uuid.MustParse(source)}.Encode() + "&sort=createdAt",
+			check: func(t *testing.T, q ArticleQuery) {
+				if q.After == nil || q.After.ID.String() != source {
+					t.Errorf("Expected the cursor to be decoded, got %+v", q.After)
+				}
+			},
+		},
+		{name: "Cursor for another sort", query: "cursor=" + Cursor{Sort: "createdAt", ID: uuid.New()}.Encode(), errors: true},
+		{name: "Cursor with relevance", query: "cursor=&sort=relevance", errors: true},
+		{name: "Invalid category", query: "category=sports", errors: true},
+		{name: "Invalid featured", query: "featured=maybe", errors: true},
+		{name: "Invalid date", query: "from=yesterday", errors: true},
+		{name: "Invalid sort", query: "sort=title", errors: true},
+		{name: "Invalid order", query: "order=up", errors: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Invalid test query: %v", err)
+			}
+			q, err := ParseArticleQuery(values)
+			if tt.errors {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", q)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			tt.check(t, q)
+		})
+	}
+}
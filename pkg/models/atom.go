@@ -0,0 +1,142 @@
+package models
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// The root of an Atom 1.0 feed
+type AtomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Title    AtomText    `xml:"title"`
+	Links    []AtomLink  `xml:"link"`
+	Language string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+// An entry represents a single article or post in an Atom feed
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      AtomText       `xml:"title"`
+	Links      []AtomLink     `xml:"link"`
+	Summary    AtomText       `xml:"summary"`
+	Content    AtomText       `xml:"content"`
+	Authors    []AtomPerson   `xml:"author"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []AtomCategory `xml:"category"`
+
+	MediaContents   []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []MediaGroup     `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+// AtomText holds text, html or xhtml constructs such as title and content
+type AtomText struct {
+	Type  string `xml:"type,attr"`
+	Body  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// AtomLink is a link element with its relation
+type AtomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+// AtomPerson describes an author or contributor
+type AtomPerson struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email"`
+}
+
+// AtomCategory is a category element; the label is optional
+type AtomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// ToFeed normalizes an Atom feed into the shared Feed model
+func (a *AtomFeed) ToFeed() *Feed {
+	feed := &Feed{
+		Format:   FeedFormatAtom,
+		Title:    a.Title.String(),
+		Link:     alternateLink(a.Links),
+		Language: a.Language,
+		Items:    make([]Item, 0, len(a.Entries)),
+	}
+
+	for _, entry := range a.Entries {
+		feed.Items = append(feed.Items, entry.ToItem())
+	}
+
+	return feed
+}
+
+// ToItem converts an Atom entry into an RSS item
+func (e *AtomEntry) ToItem() Item {
+	pubDate := e.Published
+	if pubDate == "" {
+		pubDate = e.Updated
+	}
+
+	var author string
+	if len(e.Authors) > 0 {
+		author = e.Authors[0].Name
+	}
+
+	categories := make([]string, 0, len(e.Categories))
+	for _, c := range e.Categories {
+		if c.Label != "" {
+			categories = append(categories, c.Label)
+		} else if c.Term != "" {
+			categories = append(categories, c.Term)
+		}
+	}
+
+	var enclosures []Enclosure
+	for _, link := range e.Links {
+		if link.Rel == "enclosure" {
+			enclosures = append(enclosures, Enclosure{URL: link.Href, Type: link.Type, Length: link.Length})
+		}
+	}
+
+	return Item{
+		Title:           e.Title.String(),
+		Link:            alternateLink(e.Links),
+		Description:     e.Summary.String(),
+		GUID:            strings.TrimSpace(e.ID),
+		Author:          author,
+		PubDate:         toRFC1123Z(pubDate),
+		Categories:      categories,
+		ContentEncoded:  e.Content.String(),
+		MediaContents:   e.MediaContents,
+		MediaThumbnails: e.MediaThumbnails,
+		MediaGroups:     e.MediaGroups,
+		Enclosures:      enclosures,
+	}
+}
+
+// String returns the text construct's content. XHTML content is returned as
+// the raw inner markup; text and escaped html are returned decoded.
+func (t AtomText) String() string {
+	if t.Type == "xhtml" {
+		return strings.TrimSpace(t.Inner)
+	}
+	return strings.TrimSpace(t.Body)
+}
+
+// alternateLink picks the rel="alternate" link, which is the default relation
+func alternateLink(links []AtomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+	if len(links) > 0 {
+		return links[0].Href
+	}
+	return ""
+}
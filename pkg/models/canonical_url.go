@@ -0,0 +1,82 @@
+package models
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParams are query parameters that identify a campaign or referrer
+// rather than the article itself
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "igshid": true,
+	"mc_cid": true, "mc_eid": true, "_ga": true, "_gl": true, "yclid": true,
+	"ref": true, "ref_src": true, "ocid": true, "cmpid": true,
+}
+
+// CanonicalURL normalizes an article URL so that the same story reached through
+// different links compares equal. The scheme is forced to https, the host is
+// lower-cased without a leading "www.", default ports, fragments, tracking
+// parameters and trailing slashes are removed, and remaining query parameters
+// are sorted. Values that are not absolute URLs are returned trimmed.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return raw
+	}
+
+	parsed.Scheme = "https"
+	parsed.User = nil
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+	parsed.Host = host
+
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+
+	query := parsed.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			query.Del(key)
+		}
+	}
+	parsed.RawQuery = encodeSortedQuery(query)
+
+	return parsed.String()
+}
+
+// encodeSortedQuery encodes query parameters sorted by key and then value
+func encodeSortedQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			if b.Len() > 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(url.QueryEscape(key))
+			b.WriteByte('=')
+			b.WriteString(url.QueryEscape(value))
+		}
+	}
+	return b.String()
+}
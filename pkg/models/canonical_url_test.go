@@ -0,0 +1,81 @@
+package models
+
+import "testing"
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Already canonical",
+			input:    "https://news24.com/politics/budget-2025",
+			expected: "https://news24.com/politics/budget-2025",
+		},
+		{
+			name:     "http and https compare equal",
+			input:    "http://news24.com/politics/budget-2025",
+			expected: "https://news24.com/politics/budget-2025",
+		},
+		{
+			name:     "Trailing slash removed",
+			input:    "https://news24.com/politics/budget-2025/",
+			expected: "https://news24.com/politics/budget-2025",
+		},
+		{
+			name:     "Host lower-cased and www removed",
+			input:    "https://WWW.News24.com/politics/budget-2025",
+			expected: "https://news24.com/politics/budget-2025",
+		},
+		{
+			name:     "Tracking parameters removed",
+			input:    "https://news24.com/politics/budget-2025?utm_source=rss&utm_medium=feed&fbclid=abc",
+			expected: "https://news24.com/politics/budget-2025",
+		},
+		{
+			name:     "Remaining parameters sorted",
+			input:    "https://allafrica.com/stories/?page=2&id=123&utm_campaign=x",
+			expected: "https://allafrica.com/stories?id=123&page=2",
+		},
+		{
+			name:     "Fragment and default port removed",
+			input:    "https://news24.com:443/politics/budget-2025#comments",
+			expected: "https://news24.com/politics/budget-2025",
+		},
+		{
+			name:     "Non-default port kept",
+			input:    "http://localhost:8080/article/1",
+			expected: "https://localhost:8080/article/1",
+		},
+		{
+			name:     "Root path",
+			input:    "https://www.iol.co.za/",
+			expected: "https://iol.co.za",
+		},
+		{
+			name:     "Surrounding whitespace trimmed",
+			input:    "  https://iol.co.za/news/story  ",
+			expected: "https://iol.co.za/news/story",
+		},
+		{
+			name:     "Relative value returned as is",
+			input:    "/news/story",
+			expected: "/news/story",
+		},
+		{
+			name:     "Empty value",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CanonicalURL(tt.input)
+			if got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
@@ -1,70 +1,110 @@
 package models
 
-import "strings"
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultCategoryThreshold is the confidence a group needs before MapCategories assigns it
+const DefaultCategoryThreshold = 0.5
 
 // CategoryGroup represents a group of related category keywords
 type CategoryGroup struct {
-	Name     string   // The name of the category group (e.g., "Sports", "Politics")
-	Keywords []string // Keywords that map to this category (case-insensitive)
+	Name     string            // The name of the category group (e.g., "Sports", "Politics")
+	Keywords []string          // Keywords that map to this category with full weight (case-insensitive)
+	Weighted []WeightedKeyword // Keywords with their own weight, and negative keywords
+}
+
+// WeightedKeyword is a keyword whose match counts for Weight, between 0 and 1.
+// A negative keyword instead vetoes the group for any category it matches.
+type WeightedKeyword struct {
+	Keyword  string
+	Weight   float64
+	Negative bool
+}
+
+// CategoryScore is how confident the mapper is that an article belongs to a group
+type CategoryScore struct {
+	Group      string  `json:"group"`
+	Confidence float64 `json:"confidence"`
 }
 
 // CategoryMapper handles mapping article categories to grouped categories
 type CategoryMapper struct {
-	groups []CategoryGroup
+	groups    []compiledGroup
+	threshold float64
+}
+
+type compiledGroup struct {
+	name     string
+	keywords []compiledKeyword
+}
+
+type compiledKeyword struct {
+	tokens   []string
+	weight   float64
+	negative bool
 }
 
 // NewCategoryMapper creates a new CategoryMapper with predefined groups
 func NewCategoryMapper() *CategoryMapper {
-	return &CategoryMapper{
-		groups: []CategoryGroup{
-			{
-				Name: "Sports",
-				Keywords: []string{
-					"sports", "sport", "football", "soccer", "basketball",
-					"rugby", "cricket", "tennis", "athletics", "olympics",
-				},
+	return NewCategoryMapperWithGroups(DefaultCategoryGroups())
+}
+
+// DefaultCategoryGroups returns the built-in groups, used to seed the
+// editable mapping rules and whenever they cannot be loaded
+func DefaultCategoryGroups() []CategoryGroup {
+	return []CategoryGroup{
+		{
+			Name: "Sports",
+			Keywords: []string{
+				"sports", "sport", "football", "soccer", "basketball",
+				"rugby", "cricket", "tennis", "athletics", "olympics",
 			},
-			{
-				Name: "Politics",
-				Keywords: []string{
-					"politics", "political", "government", "parliament",
-					"election", "minister", "president", "democracy",
-				},
+		},
+		{
+			Name: "Politics",
+			Keywords: []string{
+				"politics", "political", "government", "parliament",
+				"election", "minister", "president", "democracy",
 			},
-			{
-				Name: "Business",
-				Keywords: []string{
-					"business", "economy", "finance", "financial",
-					"trade", "market", "stock", "investment", "banking",
-				},
+		},
+		{
+			Name: "Business",
+			Keywords: []string{
+				"business", "economy", "finance", "financial",
+				"trade", "market", "stock", "investment", "banking",
 			},
-			{
-				Name: "Technology",
-				Keywords: []string{
-					"technology", "tech", "digital", "software",
-					"hardware", "ai", "artificial intelligence", "computing",
-				},
+		},
+		{
+			Name: "Technology",
+			Keywords: []string{
+				"technology", "tech", "digital", "software",
+				"hardware", "ai", "artificial intelligence", "computing",
 			},
-			{
-				Name: "Entertainment",
-				Keywords: []string{
-					"entertainment", "celebrity", "movie", "film",
-					"music", "television", "tv", "show", "arts", "culture",
-				},
+		},
+		{
+			Name: "Entertainment",
+			Keywords: []string{
+				"entertainment", "celebrity", "movie", "film",
+				"music", "television", "tv", "arts", "culture",
 			},
-			{
-				Name: "Health",
-				Keywords: []string{
-					"health", "medical", "medicine", "healthcare",
-					"wellness", "fitness", "hospital", "doctor",
-				},
+			// "Show" alone is as likely to be a motor or agricultural show
+			Weighted: []WeightedKeyword{{Keyword: "show", Weight: 0.4}},
+		},
+		{
+			Name: "Health",
+			Keywords: []string{
+				"health", "medical", "medicine", "healthcare",
+				"wellness", "fitness", "hospital", "doctor",
 			},
-			{
-				Name: "Education",
-				Keywords: []string{
-					"education", "school", "university", "college",
-					"student", "learning", "academic", "teaching",
-				},
+		},
+		{
+			Name: "Education",
+			Keywords: []string{
+				"education", "school", "university", "college",
+				"student", "learning", "academic", "teaching",
 			},
 		},
 	}
@@ -72,45 +112,154 @@ func NewCategoryMapper() *CategoryMapper {
 
 // NewCategoryMapperWithGroups creates a CategoryMapper with custom groups
 func NewCategoryMapperWithGroups(groups []CategoryGroup) *CategoryMapper {
-	return &CategoryMapper{
-		groups: groups,
+	cm := &CategoryMapper{threshold: DefaultCategoryThreshold}
+	for _, group := range groups {
+		cm.AddGroup(group)
 	}
+	return cm
 }
 
 // AddGroup adds a new category group to the mapper
 func (cm *CategoryMapper) AddGroup(group CategoryGroup) {
-	cm.groups = append(cm.groups, group)
+	cm.groups = append(cm.groups, compileGroup(group))
+}
+
+// Groups returns the names of the mapper's groups
+func (cm *CategoryMapper) Groups() []string {
+	names := make([]string, 0, len(cm.groups))
+	for _, group := range cm.groups {
+		names = append(names, group.name)
+	}
+	return names
+}
+
+// SetThreshold changes the confidence a group needs to be assigned by MapCategories
+func (cm *CategoryMapper) SetThreshold(threshold float64) {
+	cm.threshold = threshold
 }
 
 // MapCategories maps an array of category strings to their grouped category names
-// Returns unique category group names that match the input categories
+// Returns unique category group names whose confidence reaches the threshold
 func (cm *CategoryMapper) MapCategories(categories []string) []string {
-	if len(categories) == 0 {
-		return []string{}
+	result := []string{}
+	for _, score := range cm.ScoreCategories(categories) {
+		if score.Confidence >= cm.threshold {
+			result = append(result, score.Group)
+		}
 	}
+	return result
+}
 
-	matchedGroups := make(map[string]bool)
+// ScoreCategories rates every group matched by the categories, most confident first.
+//
+// Keywords match whole words, so "ai" matches "AI News" but not "Thailand"; a
+// multi-word keyword must appear as a phrase, and a trailing "s" or "es" on a
+// word is ignored. Within one category the strongest matching keyword sets the
+// group's score, unless a negative keyword for the group also matches. Scores
+// from several categories combine as independent evidence: 1 - Π(1 - score).
+func (cm *CategoryMapper) ScoreCategories(categories []string) []CategoryScore {
+	remaining := make([]float64, len(cm.groups)) // Π(1 - score) per group
+	for i := range remaining {
+		remaining[i] = 1
+	}
 
 	for _, category := range categories {
-		categoryLower := strings.ToLower(strings.TrimSpace(category))
-
-		// Check each group for a match
-		for _, group := range cm.groups {
-			for _, keyword := range group.Keywords {
-				if strings.Contains(categoryLower, strings.ToLower(keyword)) ||
-					strings.Contains(strings.ToLower(keyword), categoryLower) {
-					matchedGroups[group.Name] = true
-					break
-				}
+		tokens := tokenizeCategory(category)
+		if len(tokens) == 0 {
+			continue
+		}
+		for i, group := range cm.groups {
+			remaining[i] *= 1 - group.score(tokens)
+		}
+	}
+
+	scores := make([]CategoryScore, 0)
+	seen := make(map[string]int)
+	for i, group := range cm.groups {
+		confidence := 1 - remaining[i]
+		if confidence <= 0 {
+			continue
+		}
+		// Groups sharing a name, for example after AddGroup, are merged
+		if j, ok := seen[group.name]; ok {
+			scores[j].Confidence = 1 - (1-scores[j].Confidence)*(1-confidence)
+			continue
+		}
+		seen[group.name] = len(scores)
+		scores = append(scores, CategoryScore{Group: group.name, Confidence: confidence})
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Confidence > scores[j].Confidence
+	})
+	return scores
+}
+
+func compileGroup(group CategoryGroup) compiledGroup {
+	compiled := compiledGroup{name: group.Name}
+	for _, keyword := range group.Keywords {
+		if tokens := tokenizeCategory(keyword); len(tokens) > 0 {
+			compiled.keywords = append(compiled.keywords, compiledKeyword{tokens: tokens, weight: 1})
+		}
+	}
+	for _, keyword := range group.Weighted {
+		tokens := tokenizeCategory(keyword.Keyword)
+		if len(tokens) == 0 {
+			continue
+		}
+		compiled.keywords = append(compiled.keywords, compiledKeyword{
+			tokens:   tokens,
+			weight:   min(max(keyword.Weight, 0), 1),
+			negative: keyword.Negative,
+		})
+	}
+	return compiled
+}
+
+// score is the group's score for a single tokenized category
+func (g compiledGroup) score(tokens []string) float64 {
+	best := 0.0
+	for _, keyword := range g.keywords {
+		if !containsPhrase(tokens, keyword.tokens) {
+			continue
+		}
+		if keyword.negative {
+			return 0
+		}
+		best = max(best, keyword.weight)
+	}
+	return best
+}
+
+// containsPhrase reports whether phrase appears as consecutive words in tokens
+func containsPhrase(tokens, phrase []string) bool {
+	for start := 0; start+len(phrase) <= len(tokens); start++ {
+		matched := true
+		for i, word := range phrase {
+			if !sameWord(tokens[start+i], word) {
+				matched = false
+				break
 			}
 		}
+		if matched {
+			return true
+		}
 	}
+	return false
+}
 
-	// Convert map to slice
-	result := make([]string, 0, len(matchedGroups))
-	for groupName := range matchedGroups {
-		result = append(result, groupName)
+// sameWord compares words ignoring a plural "s" or "es" on the category's word
+func sameWord(token, keyword string) bool {
+	if token == keyword {
+		return true
 	}
+	rest, ok := strings.CutPrefix(token, keyword)
+	return ok && (rest == "s" || rest == "es")
+}
 
-	return result
+// tokenizeCategory lowercases a category and splits it into words
+func tokenizeCategory(category string) []string {
+	return strings.FieldsFunc(strings.ToLower(category), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
 }
@@ -167,3 +167,155 @@ func TestCategoryMapper_AddGroup(t *testing.T) {
 		t.Errorf("Expected ['Science'], got %v", result)
 	}
 }
+
+// Categories taken from South African and international feeds, with the groups
+// they should map to. Add a case here whenever a mapping bug is fixed.
+func TestCategoryMapper_RegressionCorpus(t *testing.T) {
+	mapper := NewCategoryMapper()
+
+	tests := []struct {
+		category string
+		expected []string
+	}{
+		// Substrings inside other words must not match
+		{category: "Thailand", expected: []string{}},
+		{category: "Said", expected: []string{}},
+		{category: "Mountain Biking", expected: []string{}},
+		{category: "Artificial Turf", expected: []string{}},
+		{category: "Stockvel", expected: []string{}},
+		{category: "Entertainmentweekly", expected: []string{}},
+		// Short categories must not match every keyword containing them
+		{category: "a", expected: []string{}},
+		{category: "TV", expected: []string{"Entertainment"}},
+		{category: "it", expected: []string{}},
+		// Whole words and phrases
+		{category: "AI", expected: []string{"Technology"}},
+		{category: "Artificial Intelligence", expected: []string{"Technology"}},
+		{category: "Tech & Innovation", expected: []string{"Technology"}},
+		{category: "Film & TV Reviews", expected: []string{"Entertainment"}},
+		{category: "Rugby World Cup", expected: []string{"Sports"}},
+		{category: "Premier Soccer League", expected: []string{"Sports"}},
+		{category: "Paris Olympics 2024", expected: []string{"Sports"}},
+		{category: "Minister of Finance", expected: []string{"Politics", "Business"}},
+		{category: "Public Health", expected: []string{"Health"}},
+		{category: "Student protests", expected: []string{"Education"}},
+		// Plurals
+		{category: "Markets", expected: []string{"Business"}},
+		{category: "Elections 2024", expected: []string{"Politics"}},
+		{category: "Hospitals", expected: []string{"Health"}},
+		{category: "Schools", expected: []string{"Education"}},
+		{category: "Movies", expected: []string{"Entertainment"}},
+		// Weak keywords stay below the threshold on their own
+		{category: "Rand Show", expected: []string{}},
+		// Unmapped local categories
+		{category: "Load shedding", expected: []string{}},
+		{category: "Tshwane", expected: []string{}},
+		{category: "Opinion", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			result := mapper.MapCategories([]string{tt.category})
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, result)
+			}
+			resultMap := make(map[string]bool)
+			for _, cat := range result {
+				resultMap[cat] = true
+			}
+			for _, expected := range tt.expected {
+				if !resultMap[expected] {
+					t.Errorf("Expected category '%s' not found in result: %v", expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryMapper_ScoreCategories(t *testing.T) {
+	mapper := NewCategoryMapperWithGroups([]CategoryGroup{
+		{
+			Name:     "Sports",
+			Keywords: []string{"sport", "football"},
+			Weighted: []WeightedKeyword{
+				{Keyword: "league", Weight: 0.3},
+				{Keyword: "fantasy", Negative: true},
+			},
+		},
+		{
+			Name:     "Business",
+			Weighted: []WeightedKeyword{{Keyword: "market", Weight: 0.6}},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		categories []string
+		expected   map[string]float64
+	}{
+		{
+			name:       "Full weight keyword",
+			categories: []string{"Football"},
+			expected:   map[string]float64{"Sports": 1},
+		},
+		{
+			name:       "Strongest keyword in a category wins",
+			categories: []string{"Football League"},
+			expected:   map[string]float64{"Sports": 1},
+		},
+		{
+			name:       "Weak evidence combines across categories",
+			categories: []string{"League", "League Table"},
+			expected:   map[string]float64{"Sports": 0.51},
+		},
+		{
+			name:       "Negative keyword vetoes the category",
+			categories: []string{"Fantasy Football"},
+			expected:   map[string]float64{},
+		},
+		{
+			name:       "Negative keyword only vetoes its own category",
+			categories: []string{"Fantasy Football", "Sport"},
+			expected:   map[string]float64{"Sports": 1},
+		},
+		{
+			name:       "Weighted keyword",
+			categories: []string{"Markets"},
+			expected:   map[string]float64{"Business": 0.6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := mapper.ScoreCategories(tt.categories)
+			if len(scores) != len(tt.expected) {
+				t.Fatalf("Expected %d scores, got %v", len(tt.expected), scores)
+			}
+			for _, score := range scores {
+				expected, ok := tt.expected[score.Group]
+				if !ok {
+					t.Errorf("Unexpected group %s", score.Group)
+					continue
+				}
+				if diff := score.Confidence - expected; diff > 0.001 || diff < -0.001 {
+					t.Errorf("Expected %s confidence %.3f, got %.3f", score.Group, expected, score.Confidence)
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryMapper_Threshold(t *testing.T) {
+	mapper := NewCategoryMapperWithGroups([]CategoryGroup{
+		{Name: "Business", Weighted: []WeightedKeyword{{Keyword: "market", Weight: 0.6}}},
+	})
+
+	if result := mapper.MapCategories([]string{"Market"}); len(result) != 1 {
+		t.Errorf("Expected Business at the default threshold, got %v", result)
+	}
+
+	mapper.SetThreshold(0.8)
+	if result := mapper.MapCategories([]string{"Market"}); len(result) != 0 {
+		t.Errorf("Expected no groups at threshold 0.8, got %v", result)
+	}
+}
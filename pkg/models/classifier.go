@@ -0,0 +1,139 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// TextClassifier is a multinomial naive Bayes model that assigns category
+// groups to article text. It is trained from articles whose feeds supplied
+// categories and is stored as JSON.
+type TextClassifier struct {
+	Classes    map[string]*ClassCounts `json:"classes"`
+	Vocabulary map[string]int          `json:"vocabulary"` // Occurrences of each word across all classes
+	Documents  int                     `json:"documents"`
+}
+
+// ClassCounts holds the training counts for one class
+type ClassCounts struct {
+	Documents int            `json:"documents"`
+	Words     int            `json:"words"`
+	Counts    map[string]int `json:"counts"`
+}
+
+// NewTextClassifier creates an untrained classifier
+func NewTextClassifier() *TextClassifier {
+	return &TextClassifier{
+		Classes:    make(map[string]*ClassCounts),
+		Vocabulary: make(map[string]int),
+	}
+}
+
+// Train adds a document labelled with one or more classes. HTML tags and
+// stopwords are ignored.
+func (c *TextClassifier) Train(text string, labels []string) {
+	tokens := simHashTokens(text)
+	if len(tokens) == 0 || len(labels) == 0 {
+		return
+	}
+
+	c.Documents++
+	for _, label := range labels {
+		class, ok := c.Classes[label]
+		if !ok {
+			class = &ClassCounts{Counts: make(map[string]int)}
+			c.Classes[label] = class
+		}
+		class.Documents++
+		class.Words += len(tokens)
+		for _, token := range tokens {
+			class.Counts[token]++
+		}
+	}
+	for _, token := range tokens {
+		c.Vocabulary[token]++
+	}
+}
+
+// Prune drops words seen fewer than minCount times, which are mostly names
+// and typos, to keep the stored model small
+func (c *TextClassifier) Prune(minCount int) {
+	for word, count := range c.Vocabulary {
+		if count >= minCount {
+			continue
+		}
+		delete(c.Vocabulary, word)
+		for _, class := range c.Classes {
+			if n, ok := class.Counts[word]; ok {
+				class.Words -= n
+				delete(class.Counts, word)
+			}
+		}
+	}
+}
+
+// Predict returns the posterior probability of each class for text, most
+// likely first. Words the model has never seen are ignored; text with no
+// known words yields no scores.
+func (c *TextClassifier) Predict(text string) []CategoryScore {
+	if len(c.Classes) == 0 || c.Documents == 0 {
+		return nil
+	}
+
+	var known []string
+	for _, token := range simHashTokens(text) {
+		if _, ok := c.Vocabulary[token]; ok {
+			known = append(known, token)
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+
+	// Log likelihoods with Laplace smoothing
+	vocabulary := float64(len(c.Vocabulary))
+	labels := make([]string, 0, len(c.Classes))
+	logProbs := make([]float64, 0, len(c.Classes))
+	best := math.Inf(-1)
+	for label, class := range c.Classes {
+		logProb := math.Log(float64(class.Documents) / float64(c.Documents))
+		denominator := float64(class.Words) + vocabulary
+		for _, token := range known {
+			logProb += math.Log((float64(class.Counts[token]) + 1) / denominator)
+		}
+		labels = append(labels, label)
+		logProbs = append(logProbs, logProb)
+		best = max(best, logProb)
+	}
+
+	// Normalise to probabilities, shifting by the best score to avoid underflow
+	total := 0.0
+	for i := range logProbs {
+		logProbs[i] = math.Exp(logProbs[i] - best)
+		total += logProbs[i]
+	}
+
+	scores := make([]CategoryScore, len(labels))
+	for i, label := range labels {
+		scores[i] = CategoryScore{Group: label, Confidence: logProbs[i] / total}
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Confidence != scores[j].Confidence {
+			return scores[i].Confidence > scores[j].Confidence
+		}
+		return scores[i].Group < scores[j].Group
+	})
+	return scores
+}
+
+// Classify returns up to maxLabels classes whose probability is at least minConfidence
+func (c *TextClassifier) Classify(text string, minConfidence float64, maxLabels int) []string {
+	labels := []string{}
+	for _, score := range c.Predict(text) {
+		if score.Confidence < minConfidence || len(labels) == maxLabels {
+			break
+		}
+		labels = append(labels, score.Group)
+	}
+	return labels
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func trainedClassifier() *TextClassifier {
+	classifier := NewTextClassifier()
+	training := []struct {
+		text   string
+		labels []string
+	}{
+		{"Springboks name squad for rugby championship opener against the All Blacks", []string{"Sports"}},
+		{"Bafana Bafana coach picks striker for World Cup qualifier at FNB Stadium", []string{"Sports"}},
+		{"Proteas bowl out England as cricket test swings on day three", []string{"Sports"}},
+		{"Kaizer Chiefs sign midfielder ahead of league season kickoff", []string{"Sports"}},
+		{"Parliament votes on budget as ANC and DA clash over spending", []string{"Politics"}},
+		{"President addresses nation on cabinet reshuffle and new ministers", []string{"Politics"}},
+		{"Election commission confirms voter registration weekend dates", []string{"Politics"}},
+		{"Rand firms against dollar as JSE stocks rally on inflation data", []string{"Business"}},
+		{"Reserve Bank holds repo rate as inflation eases and rand steadies", []string{"Business"}},
+		{"Mining company reports profit as platinum prices climb on JSE", []string{"Business"}},
+		{"Finance minister tables budget with new tax on sugar and fuel levy", []string{"Politics", "Business"}},
+	}
+	for _, doc := range training {
+		classifier.Train(doc.text, doc.labels)
+	}
+	return classifier
+}
+
+func TestTextClassifier_Predict(t *testing.T) {
+	classifier := trainedClassifier()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "Sports", text: "Springboks beat All Blacks in rugby thriller", expected: "Sports"},
+		{name: "Politics", text: "ANC and DA negotiate cabinet posts after election", expected: "Politics"},
+		{name: "Business", text: "JSE slips as rand weakens on inflation fears", expected: "Business"},
+		{name: "HTML ignored", text: "<p>Chiefs <strong>league</strong> match</p>", expected: "Sports"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := classifier.Predict(tt.text)
+			if len(scores) == 0 {
+				t.Fatalf("Expected scores, got none")
+			}
+			if scores[0].Group != tt.expected {
+				t.Errorf("Expected %s, got %v", tt.expected, scores)
+			}
+			total := 0.0
+			for _, score := range scores {
+				total += score.Confidence
+			}
+			if total < 0.999 || total > 1.001 {
+				t.Errorf("Expected probabilities to sum to 1, got %f", total)
+			}
+		})
+	}
+}
+
+func TestTextClassifier_UnknownText(t *testing.T) {
+	classifier := trainedClassifier()
+	if scores := classifier.Predict("Zebras graze quietly"); scores != nil {
+		t.Errorf("Expected no scores for unknown words, got %v", scores)
+	}
+	if labels := NewTextClassifier().Classify("Springboks win", 0.5, 2); len(labels) != 0 {
+		t.Errorf("Expected untrained classifier to return no labels, got %v", labels)
+	}
+}
+
+func TestTextClassifier_Classify(t *testing.T) {
+	classifier := trainedClassifier()
+
+	labels := classifier.Classify("Bafana Bafana striker scores in World Cup qualifier", 0.6, 2)
+	if len(labels) != 1 || labels[0] != "Sports" {
+		t.Errorf("Expected [Sports], got %v", labels)
+	}
+
+	if labels := classifier.Classify("Bafana Bafana striker scores in World Cup qualifier", 1.01, 2); len(labels) != 0 {
+		t.Errorf("Expected no labels above an impossible confidence, got %v", labels)
+	}
+}
+
+func TestTextClassifier_PruneAndSerialize(t *testing.T) {
+	classifier := trainedClassifier()
+	classifier.Prune(2)
+
+	if _, ok := classifier.Vocabulary["squad"]; ok {
+		t.Errorf("Expected word seen once to be pruned")
+	}
+	for label, class := range classifier.Classes {
+		words := 0
+		for _, n := range class.Counts {
+			words += n
+		}
+		if words != class.Words {
+			t.Errorf("Class %s: expected word total %d, got %d", label, words, class.Words)
+		}
+	}
+
+	data, err := json.Marshal(classifier)
+	if err != nil {
+		t.Fatalf("Marshal returned an error: %v", err)
+	}
+	var restored TextClassifier
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+	if scores := restored.Predict("rand inflation JSE"); len(scores) == 0 || scores[0].Group != "Business" {
+		t.Errorf("Expected restored model to predict Business, got %v", scores)
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ArticleContentHash fingerprints the editorial content of an article so that
+// corrections made by the publisher can be detected on the next fetch
+func ArticleContentHash(title, summary, content string) string {
+	h := sha256.New()
+	for _, field := range []string{title, summary, content} {
+		h.Write([]byte(strings.TrimSpace(field)))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
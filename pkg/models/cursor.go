@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded or
+// was issued for a different sort order
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor marks the last row of a keyset-paginated page. The next page starts
+// after the row with this (Time, ID) in the listing's sort order, so rows
+// inserted meanwhile never shift a page the way OFFSET does.
+type Cursor struct {
+	Sort string    `json:"s"` // The sort the cursor was issued for
+	Time time.Time `json:"t"`
+	ID   uuid.UUID `json:"i"`
+}
+
+// Encode returns the cursor as an opaque, URL-safe token
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token from Encode, checking it was issued for sort
+func DecodeCursor(token, sort string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil || cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// KeysetPage trims the extra row fetched to detect a following page and
+// returns the cursor for that page, or nil on the last page. key gives the
+// sort time and ID of a row.
+func KeysetPage[T any](rows []T, limit int, sort string, key func(T) (time.Time, uuid.UUID)) ([]T, *Cursor) {
+	if len(rows) <= limit {
+		return rows, nil
+	}
+	rows = rows[:limit]
+	t, id := key(rows[limit-1])
+	return rows, &Cursor{Sort: sort, Time: t, ID: id}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	cursor := Cursor{
+		Sort: "publishedAt",
+		Time: time.Date(2024, 3, 14, 9, 30, 15, 123456000, time.UTC),
+		ID:   uuid.MustParse("6f1c7f0e-3f4b-4b43-9a63-2f4bb3f1d9a1"),
+	}
+
+	decoded, err := DecodeCursor(cursor.Encode(), "publishedAt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !decoded.Time.Equal(cursor.Time) || decoded.ID != cursor.ID {
+		t.Errorf("Expected %+v, got %+v", cursor, decoded)
+	}
+
+	if _, err := DecodeCursor(cursor.Encode(), "createdAt"); err != ErrInvalidCursor {
+		t.Errorf("Expected a cursor for another sort to be rejected, got %v", err)
+	}
+	for _, token := range []string{"not a cursor", "e30", ""} {
+		if _, err := DecodeCursor(token, "publishedAt"); err != ErrInvalidCursor {
+			t.Errorf("Expected %q to be rejected, got %v", token, err)
+		}
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	type row struct {
+		at time.Time
+		id uuid.UUID
+	}
+	start := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	rows := make([]row, 4)
+	for i := range rows {
+		rows[i] = row{at: start.Add(-time.Duration(i) * time.Hour), id: uuid.New()}
+	}
+	key := func(r row) (time.Time, uuid.UUID) { return r.at, r.id }
+
+	page, next := KeysetPage(rows, 3, "createdAt", key)
+	if len(page) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(page))
+	}
+	if next == nil || next.ID != rows[2].id || !next.Time.Equal(rows[2].at) || next.Sort != "createdAt" {
+		t.Errorf("Expected a cursor at the third row, got %+v", next)
+	}
+
+	page, next = KeysetPage(rows[:3], 3, "createdAt", key)
+	if len(page) != 3 || next != nil {
+		t.Errorf("Expected the last page without a cursor, got %d rows and %+v", len(page), next)
+	}
+}
@@ -0,0 +1,193 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PubDate describes how an item's publication date was resolved
+type PubDate struct {
+	Time      time.Time
+	Layout    string // The layout that matched; empty when Estimated
+	Rescued   bool   // True when the raw value was not strict RFC1123Z
+	Estimated bool   // True when the value could not be parsed and Time is the fetch time
+}
+
+// pubDateLayouts are tried in order after the raw value has been normalized.
+// Weekday names are stripped during normalization, so none of these carry one.
+var pubDateLayouts = buildPubDateLayouts()
+
+// zoneOffsets maps zone abbreviations seen in feeds to numeric offsets.
+// time.Parse accepts unknown abbreviations but silently treats them as UTC.
+var zoneOffsets = map[string]string{
+	"UT": "+0000", "UTC": "+0000", "GMT": "+0000", "Z": "+0000",
+	"SAST": "+0200", "CAT": "+0200", "EAT": "+0300", "WAT": "+0100",
+	"WET": "+0000", "WEST": "+0100", "BST": "+0100", "IST": "+0530",
+	"CET": "+0100", "CEST": "+0200", "EET": "+0200", "EEST": "+0300",
+	"EST": "-0500", "EDT": "-0400", "CST": "-0600", "CDT": "-0500",
+	"MST": "-0700", "MDT": "-0600", "PST": "-0800", "PDT": "-0700",
+	"AEST": "+1000", "AEDT": "+1100",
+}
+
+// monthNames maps English and common African/European month names and
+// abbreviations (lower case, without trailing dots) to English abbreviations.
+var monthNames = map[string]string{
+	// English
+	"january": "Jan", "february": "Feb", "march": "Mar", "april": "Apr", "may": "May", "june": "Jun",
+	"july": "Jul", "august": "Aug", "september": "Sep", "sept": "Sep", "october": "Oct",
+	"november": "Nov", "december": "Dec",
+	"jan": "Jan", "feb": "Feb", "mar": "Mar", "apr": "Apr", "jun": "Jun", "jul": "Jul",
+	"aug": "Aug", "sep": "Sep", "oct": "Oct", "nov": "Nov", "dec": "Dec",
+	// Afrikaans
+	"januarie": "Jan", "februarie": "Feb", "maart": "Mar", "mrt": "Mar", "mei": "May", "junie": "Jun",
+	"julie": "Jul", "augustus": "Aug", "oktober": "Oct", "okt": "Oct", "desember": "Dec", "des": "Dec",
+	// isiZulu
+	"januwari": "Jan", "febhuwari": "Feb", "mashi": "Mar", "ephreli": "Apr", "meyi": "May",
+	"juni": "Jun", "julayi": "Jul", "agasti": "Aug", "septhemba": "Sep", "okthoba": "Oct",
+	"novemba": "Nov", "disemba": "Dec",
+	// Swahili
+	"januari": "Jan", "februari": "Feb", "machi": "Mar", "aprili": "Apr", "julai": "Jul",
+	"agosti": "Aug", "septemba": "Sep", "oktoba": "Oct", "desemba": "Dec",
+	// French
+	"janvier": "Jan", "janv": "Jan", "février": "Feb", "févr": "Feb", "fevrier": "Feb", "mars": "Mar",
+	"avril": "Apr", "avr": "Apr", "mai": "May", "juin": "Jun", "juillet": "Jul", "juil": "Jul",
+	"août": "Aug", "aout": "Aug", "septembre": "Sep", "octobre": "Oct", "novembre": "Nov",
+	"décembre": "Dec", "déc": "Dec", "decembre": "Dec",
+	// Portuguese and Spanish
+	"janeiro": "Jan", "fevereiro": "Feb", "fev": "Feb", "março": "Mar", "marco": "Mar", "abril": "Apr",
+	"abr": "Apr", "maio": "May", "junho": "Jun", "julho": "Jul", "agosto": "Aug", "ago": "Aug",
+	"setembro": "Sep", "set": "Sep", "outubro": "Oct", "out": "Oct", "novembro": "Nov",
+	"dezembro": "Dec", "dez": "Dec", "enero": "Jan", "ene": "Jan", "febrero": "Feb", "marzo": "Mar",
+	"mayo": "May", "junio": "Jun", "julio": "Jul", "septiembre": "Sep", "octubre": "Oct",
+	"noviembre": "Nov", "diciembre": "Dec", "dic": "Dec",
+	// German
+	"januar": "Jan", "februar": "Feb", "märz": "Mar", "mär": "Mar", "dezember": "Dec",
+}
+
+// weekdayNames lists weekday names that may lead a date without a comma
+var weekdayNames = map[string]bool{
+	"mon": true, "tue": true, "tues": true, "wed": true, "thu": true, "thur": true, "thurs": true,
+	"fri": true, "sat": true, "sun": true, "monday": true, "tuesday": true, "wednesday": true,
+	"thursday": true, "friday": true, "saturday": true, "sunday": true,
+	"maandag": true, "dinsdag": true, "woensdag": true, "donderdag": true, "vrydag": true,
+	"saterdag": true, "sondag": true,
+	"umsombuluko": true, "ulwesibili": true, "ulwesithathu": true, "ulwesine": true,
+	"ulwesihlanu": true, "umgqibelo": true, "isonto": true,
+	"jumatatu": true, "jumanne": true, "jumatano": true, "alhamisi": true, "ijumaa": true,
+	"jumamosi": true, "jumapili": true,
+	"lundi": true, "mardi": true, "mercredi": true, "jeudi": true, "vendredi": true,
+	"samedi": true, "dimanche": true,
+}
+
+// connectorWords are dropped from localized dates such as "14 de março de 2025"
+var connectorWords = map[string]bool{
+	"de": true, "à": true, "às": true, "a": true, "om": true, "um": true, "at": true, "le": true,
+}
+
+var (
+	hourSeparatorPattern = regexp.MustCompile(`(\d{1,2})h(\d{2})`)
+	whitespacePattern    = regexp.MustCompile(`\s+`)
+)
+
+// ParsePubDate resolves a feed date string. Strict RFC1123Z is tried first;
+// otherwise the value is normalized and a ranked list of layouts is tried.
+// Values that cannot be parsed fall back to fetchedAt and are flagged Estimated.
+func ParsePubDate(value string, fetchedAt time.Time) PubDate {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC1123Z, value); err == nil {
+		return PubDate{Time: t, Layout: time.RFC1123Z}
+	}
+
+	normalized := normalizePubDate(value)
+	if normalized != "" {
+		for _, layout := range pubDateLayouts {
+			if t, err := time.Parse(layout, normalized); err == nil {
+				return PubDate{Time: t, Layout: layout, Rescued: true}
+			}
+		}
+	}
+
+	return PubDate{Time: fetchedAt, Rescued: true, Estimated: true}
+}
+
+// normalizePubDate drops weekdays and connector words, translates month names
+// to English and replaces zone abbreviations with numeric offsets
+func normalizePubDate(value string) string {
+	value = hourSeparatorPattern.ReplaceAllString(value, "$1:$2")
+	value = whitespacePattern.ReplaceAllString(value, " ")
+
+	tokens := strings.Split(value, " ")
+	result := make([]string, 0, len(tokens))
+
+	for i, token := range tokens {
+		key := strings.ToLower(strings.TrimRight(token, ".,"))
+		trailingComma := strings.HasSuffix(token, ",")
+
+		if month, ok := monthNames[key]; ok {
+			if trailingComma {
+				month += ","
+			}
+			result = append(result, month)
+			continue
+		}
+
+		if i == 0 && (weekdayNames[key] || (trailingComma && isAlpha(key))) {
+			continue
+		}
+
+		if connectorWords[key] {
+			continue
+		}
+
+		if i == len(tokens)-1 && isAlpha(key) && key != "am" && key != "pm" {
+			if offset, ok := zoneOffsets[strings.ToUpper(key)]; ok {
+				result = append(result, offset)
+			}
+			// Unknown abbreviations are dropped and the time treated as UTC
+			continue
+		}
+
+		result = append(result, token)
+	}
+
+	return strings.Join(result, " ")
+}
+
+func buildPubDateLayouts() []string {
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05Z0700",
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05Z07:00",
+		"Jan _2 15:04:05 -0700 2006",
+	}
+
+	dates := []string{"2 Jan 2006", "2 Jan 06", "Jan 2, 2006", "Jan 2 2006", "2006-01-02"}
+	times := []string{"15:04:05", "15:04", "3:04:05 PM", "3:04 PM", "3:04PM"}
+	zones := []string{" -0700", " -07:00", ""}
+
+	for _, date := range dates {
+		for _, clock := range times {
+			for _, zone := range zones {
+				layouts = append(layouts, date+" "+clock+zone)
+			}
+		}
+	}
+
+	return append(layouts, "2 Jan 2006", "Jan 2, 2006", "2006-01-02")
+}
+
+func isAlpha(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
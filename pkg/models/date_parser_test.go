@@ -0,0 +1,142 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePubDate(t *testing.T) {
+	fetchedAt := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+	sast := time.FixedZone("", 2*60*60)
+
+	tests := []struct {
+		name          string
+		value         string
+		expected      time.Time
+		expectRescued bool
+	}{
+		{
+			name:     "Strict RFC1123Z",
+			value:    "Fri, 14 Mar 2025 08:00:00 +0200",
+			expected: time.Date(2025, 3, 14, 8, 0, 0, 0, sast),
+		},
+		{
+			name:          "RFC1123 with GMT zone name",
+			value:         "Fri, 14 Mar 2025 06:00:00 GMT",
+			expected:      time.Date(2025, 3, 14, 6, 0, 0, 0, time.UTC),
+			expectRescued: true,
+		},
+		{
+			name:          "African zone abbreviation",
+			value:         "Fri, 14 Mar 2025 08:00:00 SAST",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, sast),
+			expectRescued: true,
+		},
+		{
+			name:          "Single digit day",
+			value:         "Sun, 2 Mar 2025 08:00:00 +0200",
+			expected:      time.Date(2025, 3, 2, 8, 0, 0, 0, sast),
+			expectRescued: true,
+		},
+		{
+			name:          "Missing seconds",
+			value:         "Fri, 14 Mar 2025 08:00 +0200",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, sast),
+			expectRescued: true,
+		},
+		{
+			name:          "RFC3339",
+			value:         "2025-03-14T08:00:00+02:00",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, sast),
+			expectRescued: true,
+		},
+		{
+			name:          "RFC3339 with fractional seconds",
+			value:         "2025-03-14T06:00:00.123Z",
+			expected:      time.Date(2025, 3, 14, 6, 0, 0, 123000000, time.UTC),
+			expectRescued: true,
+		},
+		{
+			name:          "Afrikaans day and month names",
+			value:         "Vrydag, 14 Maart 2025 08:00:00 +0200",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, sast),
+			expectRescued: true,
+		},
+		{
+			name:          "French date with h separator",
+			value:         "vendredi 14 mars 2025 08h00 +0200",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, sast),
+			expectRescued: true,
+		},
+		{
+			name:          "Portuguese connector words",
+			value:         "14 de março de 2025 08:00",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, time.UTC),
+			expectRescued: true,
+		},
+		{
+			name:          "English long form with meridiem",
+			value:         "March 14, 2025 8:00 AM",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, time.UTC),
+			expectRescued: true,
+		},
+		{
+			name:          "Unknown zone abbreviation treated as UTC",
+			value:         "Fri, 14 Mar 2025 08:00:00 XYZT",
+			expected:      time.Date(2025, 3, 14, 8, 0, 0, 0, time.UTC),
+			expectRescued: true,
+		},
+		{
+			name:          "Date only",
+			value:         "2025-03-14",
+			expected:      time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			expectRescued: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParsePubDate(tt.value, fetchedAt)
+
+			if result.Estimated {
+				t.Fatalf("Expected %q to parse, but fell back to fetch time", tt.value)
+			}
+			if !result.Time.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v (layout %q)", tt.expected, result.Time, result.Layout)
+			}
+			if result.Rescued != tt.expectRescued {
+				t.Errorf("Expected Rescued %t, got %t", tt.expectRescued, result.Rescued)
+			}
+		})
+	}
+}
+
+func TestParsePubDate_FallsBackToFetchTime(t *testing.T) {
+	fetchedAt := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"", "yesterday", "not a date at all"} {
+		result := ParsePubDate(value, fetchedAt)
+		if !result.Estimated || !result.Rescued {
+			t.Errorf("Expected %q to be flagged as estimated, got %+v", value, result)
+		}
+		if !result.Time.Equal(fetchedAt) {
+			t.Errorf("Expected fetch time for %q, got %v", value, result.Time)
+		}
+	}
+}
+
+func TestItemToArticle_EstimatedPublishedAt(t *testing.T) {
+	fetchedAt := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+	item := Item{Title: "Undated", Link: "https://example.com/undated", PubDate: "soon"}
+
+	article, err := item.ToArticle("en", item.PublishedAt(fetchedAt))
+	if err != nil {
+		t.Fatalf("Did not expect an error but got: %v", err)
+	}
+	if !article.PublishedAtEstimated {
+		t.Errorf("Expected article to be flagged with an estimated publish date")
+	}
+	if !article.PublishedAt.Equal(fetchedAt) {
+		t.Errorf("Expected publish date %v, got %v", fetchedAt, article.PublishedAt)
+	}
+}
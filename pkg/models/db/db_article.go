@@ -8,17 +8,35 @@ import (
 
 type Article struct {
 	Model
-	Title       string         `json:"title,"`
-	Language    string         `json:"language,"`
-	OriginalUrl string         `json:"originalUrl" gorm:"index"`
-	Summary     string         `json:"summary"`
-	ContentBody string         `json:"contentBody"`
-	PublishedAt time.Time      `json:"publishedAt"`
-	IsFeatured  bool           `json:"isFeatured"`
-	SourceID    *uuid.UUID     `json:"sourceId" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	Source      Source         `json:"source"`
-	RegionID    *string        `json:"regionID" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	Region      Region         `json:"region"`
-	Categories  []*Category    `gorm:"many2many:article_categories;constraint:OnDelete:CASCADE;" json:"categories"`
-	Images      []ArticleImage `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE;" json:"images"`
+	Title                string         `json:"title,"`
+	Language             string         `json:"language,"`
+	DetectedLanguage     string         `json:"detectedLanguage" gorm:"index"` // ISO 639-1 code guessed from the title and summary
+	LanguageConfidence   float64        `json:"languageConfidence"`
+	OriginalUrl          string         `json:"originalUrl" gorm:"index"`
+	CanonicalUrl         string         `json:"canonicalUrl" gorm:"uniqueIndex:idx_article_canonical_url,where:canonical_url <> ''"`
+	GUID                 string         `json:"guid" gorm:"column:guid;uniqueIndex:idx_article_source_guid,priority:2,where:guid <> ''"`
+	Summary              string         `json:"summary"`
+	SummaryText          string         `json:"summaryText"`
+	ContentBody          string         `json:"contentBody"`
+	ContentText          string         `json:"contentText"`
+	ContentExtractedAt   *time.Time     `json:"contentExtractedAt"`
+	PublishedAt          time.Time      `json:"publishedAt"`
+	PublishedAtEstimated bool           `json:"publishedAtEstimated"`
+	IsFeatured           bool           `json:"isFeatured"`
+	SourceID             *uuid.UUID     `json:"sourceId" gorm:"uniqueIndex:idx_article_source_guid,priority:1;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Source               Source         `json:"source"`
+	RegionID             *string        `json:"regionID" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Region               Region         `json:"region"`
+	Categories           []*Category    `gorm:"many2many:article_categories;constraint:OnDelete:CASCADE;" json:"categories"`
+	CategoriesInferred   bool           `json:"categoriesInferred"` // Categories were predicted from the text because the feed had none
+	CategoryDefaulted    bool           `json:"categoryDefaulted"`  // The category is the source's default because none of the feed's mapped to a group
+	Images               []ArticleImage `gorm:"foreignKey:ArticleID;constraint:OnDelete:CASCADE;" json:"images"`
+	SimHash              int64          `json:"-"`
+	ContentHash          string         `json:"-"`
+	RevisionCount        int            `json:"revisionCount"`
+	RevisedAt            *time.Time     `json:"revisedAt"`
+	ClusterID            *uuid.UUID     `json:"clusterId" gorm:"index;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Cluster              *StoryCluster  `json:"cluster,omitempty"`
+	SearchRank           float64        `json:"searchRank,omitempty" gorm:"->;-:migration"` // Only selected when searching
+	SearchHeadline       string         `json:"headline,omitempty" gorm:"->;-:migration"`   // Matched words are wrapped in <mark>
 }
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -8,6 +11,43 @@ type ArticleImage struct {
 	Model
 	ArticleID uuid.UUID `gorm:"type:uuid;index"`
 	IsMain    bool      `json:"isMain"`
-	URL       string    `json:"url"`
+	URL       string    `json:"url"` // The publisher's original URL, served by /media/{id} until the image is stored
 	AltText   string    `json:"altText,omitempty"`
+	Width     int       `json:"width,omitempty"`
+	Height    int       `json:"height,omitempty"`
+
+	// Local copy, set once the image has been downloaded into media storage
+	StorageKey   string     `json:"-"`
+	ThumbnailKey string     `json:"-"`
+	ContentType  string     `json:"contentType,omitempty"`
+	ByteSize     int64      `json:"byteSize,omitempty"`
+	StoredAt     *time.Time `json:"storedAt,omitempty"`
+}
+
+// MediaURL is the path the stored copy of the image is served from, or empty
+// while it has not been stored
+func (i ArticleImage) MediaURL() string {
+	if i.StoredAt == nil || i.StorageKey == "" {
+		return ""
+	}
+	return "/media/" + i.ID.String()
+}
+
+// ThumbnailURL is the path of the image's thumbnail, or empty when it has none
+func (i ArticleImage) ThumbnailURL() string {
+	if i.ThumbnailKey == "" || i.MediaURL() == "" {
+		return ""
+	}
+	return i.MediaURL() + "?size=thumb"
+}
+
+// MarshalJSON adds the media and thumbnail paths, so clients can use the
+// stored copies instead of hotlinking the publisher
+func (i ArticleImage) MarshalJSON() ([]byte, error) {
+	type articleImage ArticleImage
+	return json.Marshal(struct {
+		articleImage
+		MediaURL     string `json:"mediaUrl,omitempty"`
+		ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+	}{articleImage(i), i.MediaURL(), i.ThumbnailURL()})
 }
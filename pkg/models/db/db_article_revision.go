@@ -0,0 +1,22 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleRevision records a change to an article's content detected on a later fetch.
+// Diffs use [-removed-] and {+added+} markers around changed words.
+type ArticleRevision struct {
+	Model
+	ArticleID       uuid.UUID `json:"articleId" gorm:"index"`
+	PreviousTitle   string    `json:"previousTitle"`
+	PreviousSummary string    `json:"previousSummary"`
+	TitleDiff       string    `json:"titleDiff"`
+	SummaryDiff     string    `json:"summaryDiff"`
+	ContentDiff     string    `json:"contentDiff"`
+	PreviousHash    string    `json:"previousHash"`
+	ContentHash     string    `json:"contentHash"`
+	DetectedAt      time.Time `json:"detectedAt"`
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"github.com/google/uuid"
+)
+
+// CategoryGroup is an editor-managed group that feed categories are mapped into.
+// A feed category joins the group when it matches one of the group's keywords.
+type CategoryGroup struct {
+	Model
+	Name     string            `json:"name" gorm:"uniqueIndex;not null"`
+	Keywords []CategoryKeyword `json:"keywords" gorm:"foreignKey:GroupID;constraint:OnDelete:CASCADE;"`
+}
+
+// CategoryKeyword is a keyword that maps feed categories into its group.
+// Weight, between 0 and 1, is how strongly a match suggests the group; a
+// negative keyword stops the group being assigned from that category.
+type CategoryKeyword struct {
+	Model
+	GroupID  uuid.UUID `json:"groupId" gorm:"not null;uniqueIndex:idx_category_keyword_group"`
+	Keyword  string    `json:"keyword" gorm:"not null;uniqueIndex:idx_category_keyword_group"`
+	Weight   float64   `json:"weight" gorm:"not null;default:1"`
+	Negative bool      `json:"negative" gorm:"not null;default:false"`
+}
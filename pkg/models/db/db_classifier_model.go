@@ -0,0 +1,14 @@
+package db
+
+import "time"
+
+// ClassifierModel is a trained text classifier stored as JSON. The newest
+// model with a given name is the one in use.
+type ClassifierModel struct {
+	Model
+	Name      string    `json:"name" gorm:"index;not null"`
+	Data      string    `json:"-" gorm:"type:text;not null"`
+	Documents int       `json:"documents"`
+	Classes   int       `json:"classes"`
+	TrainedAt time.Time `json:"trainedAt"`
+}
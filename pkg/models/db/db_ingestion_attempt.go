@@ -0,0 +1,25 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IngestionAttempt struct {
+	Model
+	RunID          *uuid.UUID `json:"runId" gorm:"index"`
+	SourceID       uuid.UUID  `json:"sourceId" gorm:"index:idx_ingestion_attempt_source_started"`
+	FeedUrl        string     `json:"feedUrl"`
+	Status         string     `json:"status"`
+	HttpStatus     int        `json:"httpStatus"`
+	ItemsSeen      int        `json:"itemsSeen"`
+	ItemsNew       int        `json:"itemsNew"`
+	ItemsDuplicate int        `json:"itemsDuplicate"`
+	ItemsUpdated   int        `json:"itemsUpdated"`
+	ItemsFailed    int        `json:"itemsFailed"`
+	DatesRescued   int        `json:"datesRescued"`
+	StartedAt      time.Time  `json:"startedAt" gorm:"index:idx_ingestion_attempt_source_started"`
+	DurationMs     int64      `json:"durationMs"`
+	Error          string     `json:"error,omitempty"`
+}
@@ -0,0 +1,22 @@
+package db
+
+import "time"
+
+type IngestionRun struct {
+	Model
+	Trigger        string             `json:"trigger"`
+	StartedAt      time.Time          `json:"startedAt" gorm:"index"`
+	FinishedAt     *time.Time         `json:"finishedAt"`
+	DurationMs     int64              `json:"durationMs"`
+	SourcesTotal   int                `json:"sourcesTotal"`
+	Succeeded      int                `json:"succeeded"`
+	NotModified    int                `json:"notModified"`
+	Failed         int                `json:"failed"`
+	Skipped        int                `json:"skipped"`
+	ItemsNew       int                `json:"itemsNew"`
+	ItemsDuplicate int                `json:"itemsDuplicate"`
+	ItemsUpdated   int                `json:"itemsUpdated"`
+	ItemsFailed    int                `json:"itemsFailed"`
+	Error          string             `json:"error,omitempty"`
+	Attempts       []IngestionAttempt `json:"attempts,omitempty" gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE;"`
+}
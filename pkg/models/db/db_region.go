@@ -2,7 +2,7 @@ package db
 
 type Region struct {
 	Model
-	Name     string    `json:"name"`
-	Slug     string    `json:"slug"`
-	Articles []Article `json:"articles"`
+	Name     string    `json:"name" gorm:"not null"`
+	Slug     string    `json:"slug" gorm:"uniqueIndex"`
+	Articles []Article `json:"articles,omitempty"`
 }
@@ -1,8 +1,63 @@
 package db
 
+import "time"
+
 type Source struct {
 	Model
 	Name       string `json:"name" gorm:"uniqueIndex:unique_source_name_website"`
 	WebsiteUrl string `json:"websiteUrl" gorm:"uniqueIndex:unique_source_name_website"`
 	RssFeedUrl string `json:"rssFeedUrl"`
+
+	// Fetch each new article's OriginalUrl and replace the feed content with
+	// the extracted article, for feeds that only publish teasers
+	ExtractFullText bool `json:"extractFullText"`
+
+	// Defaults applied to the source's articles when its feed does not say.
+	// DefaultCategory names a category group, used when no feed category maps to one.
+	DefaultLanguage string  `json:"defaultLanguage"`
+	DefaultRegionID *string `json:"defaultRegionId" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	DefaultRegion   *Region `json:"defaultRegion,omitempty"`
+	DefaultCategory string  `json:"defaultCategory"`
+
+	// Conditional fetch state, refreshed after every fetch of RssFeedUrl
+	ETag           string     `json:"etag" gorm:"column:etag"`
+	LastModified   string     `json:"lastModified"`
+	LastFetchedAt  *time.Time `json:"lastFetchedAt"`
+	LastStatusCode int        `json:"lastStatusCode"`
+
+	// Failure tracking; a source is skipped until NextFetchAt and stops being
+	// fetched altogether once Disabled
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError"`
+	NextFetchAt         *time.Time `json:"nextFetchAt"`
+	Disabled            bool       `json:"disabled" gorm:"index"`
+	DisabledAt          *time.Time `json:"disabledAt"`
+	DisabledReason      string     `json:"disabledReason"`
+}
+
+// ClearFetchState resets the fetch and failure fields, which only ingestion sets
+func (s *Source) ClearFetchState() {
+	s.ETag, s.LastModified, s.LastFetchedAt, s.LastStatusCode = "", "", nil, 0
+	s.ConsecutiveFailures, s.LastError, s.NextFetchAt = 0, "", nil
+	s.Disabled, s.DisabledAt, s.DisabledReason = false, nil, ""
+}
+
+// IsDue reports whether the source should be fetched at the given time
+func (s *Source) IsDue(now time.Time) bool {
+	if s.Disabled {
+		return false
+	}
+	return s.NextFetchAt == nil || !now.Before(*s.NextFetchAt)
+}
+
+// ApplyDefaults fills in an article's language and region from the source
+// when the feed left them empty
+func (s *Source) ApplyDefaults(article *Article) {
+	if article.Language == "" {
+		article.Language = s.DefaultLanguage
+	}
+	if article.RegionID == nil && s.DefaultRegionID != nil {
+		regionID := *s.DefaultRegionID
+		article.RegionID = &regionID
+	}
 }
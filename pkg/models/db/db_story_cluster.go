@@ -0,0 +1,15 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoryCluster groups near-identical articles published by different sources
+type StoryCluster struct {
+	Model
+	RepresentativeID uuid.UUID `json:"representativeId"`
+	ArticleCount     int       `json:"articleCount"`
+	LastSeenAt       time.Time `json:"lastSeenAt" gorm:"index"`
+}
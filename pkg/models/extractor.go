@@ -0,0 +1,358 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ErrNoArticleContent is returned when a page has no block of text that looks like an article
+var ErrNoArticleContent = errors.New("no article content found")
+
+// minParagraphLength is the shortest paragraph that counts towards a candidate's score
+const minParagraphLength = 25
+
+// ExtractedArticle is the main content of a web page with boilerplate removed
+type ExtractedArticle struct {
+	Title    string
+	HTML     string // Cleaned article HTML with only structural tags and no attributes besides href, src and alt
+	Text     string // Plain text with paragraphs separated by blank lines
+	ImageURL string // The page's og:image or twitter:image, if it declares one
+}
+
+var (
+	// Elements that never hold article content
+	boilerplateTags = map[atom.Atom]bool{
+		atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Iframe: true,
+		atom.Form: true, atom.Nav: true, atom.Header: true, atom.Footer: true,
+		atom.Aside: true, atom.Button: true, atom.Svg: true, atom.Input: true,
+		atom.Select: true, atom.Textarea: true, atom.Object: true, atom.Embed: true,
+	}
+
+	// Tags kept in the cleaned output; everything else is unwrapped
+	allowedTags = map[atom.Atom]bool{
+		atom.P: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+		atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Blockquote: true, atom.Pre: true,
+		atom.Code: true, atom.A: true, atom.Img: true, atom.Figure: true, atom.Figcaption: true,
+		atom.Strong: true, atom.Em: true, atom.B: true, atom.I: true, atom.Br: true,
+	}
+
+	negativeClassPattern = regexp.MustCompile(`(?i)comment|share|social|sidebar|related|promo|advert|sponsor|banner|cookie|newsletter|subscribe|footer|masthead|menu|breadcrumb|popup|modal|byline|author|tags|widget|(^|[\s_-])ads?([\s_-]|$)`)
+	positiveClassPattern = regexp.MustCompile(`(?i)article|content|story|body|post|entry|main|text`)
+)
+
+// ExtractArticle finds the main article in a full HTML page, readability style.
+// Paragraphs are scored by length and punctuation and their scores credited to
+// their ancestors; the best scoring container is cleaned and returned. Relative
+// links and image sources are resolved against pageURL when it is absolute.
+func ExtractArticle(pageHTML, pageURL string) (*ExtractedArticle, error) {
+	doc, err := html.Parse(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil, err
+	}
+
+	title := pageTitle(doc)
+	imageURL := pageImage(doc, pageURL)
+	removeBoilerplate(doc)
+
+	candidate := bestCandidate(doc)
+	if candidate == nil {
+		return nil, ErrNoArticleContent
+	}
+
+	base := parseBaseURL(pageURL)
+
+	var htmlOut, textOut strings.Builder
+	for c := candidate.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == atom.H1 {
+			continue // The headline is stored separately as the title
+		}
+		renderClean(&htmlOut, c, base)
+	}
+	writeText(&textOut, candidate)
+
+	text := strings.TrimSpace(textOut.String())
+	if text == "" {
+		return nil, ErrNoArticleContent
+	}
+
+	return &ExtractedArticle{
+		Title:    title,
+		HTML:     strings.TrimSpace(htmlOut.String()),
+		Text:     text,
+		ImageURL: imageURL,
+	}, nil
+}
+
+// pageTitle prefers og:title, which omits the site name, over <title>
+func pageTitle(doc *html.Node) string {
+	var title, ogTitle string
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.Title:
+			if title == "" {
+				title = strings.TrimSpace(nodeText(n))
+			}
+		case atom.Meta:
+			if attr(n, "property") == "og:title" && ogTitle == "" {
+				ogTitle = strings.TrimSpace(attr(n, "content"))
+			}
+		}
+		return true
+	})
+	if ogTitle != "" {
+		return ogTitle
+	}
+	return title
+}
+
+// removeBoilerplate detaches elements that cannot hold article content, and
+// elements whose class or id marks them as navigation, ads or comments
+func removeBoilerplate(doc *html.Node) {
+	var remove []*html.Node
+	walk(doc, func(n *html.Node) bool {
+		if n.Type == html.CommentNode {
+			remove = append(remove, n)
+			return false
+		}
+		if n.Type != html.ElementNode || n.DataAtom == atom.Html || n.DataAtom == atom.Body {
+			return true
+		}
+		if boilerplateTags[n.DataAtom] {
+			remove = append(remove, n)
+			return false
+		}
+		hints := attr(n, "class") + " " + attr(n, "id")
+		if negativeClassPattern.MatchString(hints) && !positiveClassPattern.MatchString(hints) {
+			remove = append(remove, n)
+			return false
+		}
+		return true
+	})
+	for _, n := range remove {
+		n.Parent.RemoveChild(n)
+	}
+}
+
+// bestCandidate scores every paragraph and returns the ancestor with the
+// highest score after penalising containers that are mostly links
+func bestCandidate(doc *html.Node) *html.Node {
+	scores := make(map[*html.Node]float64)
+	var order []*html.Node
+
+	credit := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, seen := scores[n]; !seen {
+			scores[n] = containerWeight(n)
+			order = append(order, n)
+		}
+		scores[n] += score
+	}
+
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || (n.DataAtom != atom.P && n.DataAtom != atom.Pre) {
+			return true
+		}
+		text := strings.TrimSpace(nodeText(n))
+		if len(text) < minParagraphLength {
+			return false
+		}
+		score := 1 + float64(strings.Count(text, ",")) + min(float64(len(text))/100, 3)
+		credit(n.Parent, score)
+		if n.Parent != nil {
+			credit(n.Parent.Parent, score/2)
+		}
+		return false
+	})
+
+	var best *html.Node
+	bestScore := 0.0
+	for _, n := range order {
+		score := scores[n] * (1 - linkDensity(n))
+		if score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+	return best
+}
+
+// containerWeight is the starting score of a container based on its tag and class
+func containerWeight(n *html.Node) float64 {
+	weight := 0.0
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		weight += 10
+	case atom.Div, atom.Section:
+		weight += 5
+	case atom.Blockquote, atom.Pre, atom.Td:
+		weight += 3
+	case atom.Ul, atom.Ol, atom.Li, atom.Form, atom.Dl:
+		weight -= 3
+	}
+
+	hints := attr(n, "class") + " " + attr(n, "id")
+	if positiveClassPattern.MatchString(hints) {
+		weight += 25
+	}
+	if negativeClassPattern.MatchString(hints) {
+		weight -= 25
+	}
+	return weight
+}
+
+// linkDensity is the fraction of a node's text that sits inside links
+func linkDensity(n *html.Node) float64 {
+	total := len(strings.TrimSpace(nodeText(n)))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.ElementNode && c.DataAtom == atom.A {
+			linked += len(strings.TrimSpace(nodeText(c)))
+			return false
+		}
+		return true
+	})
+	return float64(linked) / float64(total)
+}
+
+// renderClean writes n keeping only allowed tags and attributes. Disallowed
+// elements are unwrapped, and lists that are mostly links are dropped.
+func renderClean(b *strings.Builder, n *html.Node, base *url.URL) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(collapseSpace(n.Data)))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if (n.DataAtom == atom.Ul || n.DataAtom == atom.Ol) && linkDensity(n) > 0.5 {
+		return
+	}
+
+	if !allowedTags[n.DataAtom] {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			renderClean(b, c, base)
+		}
+		return
+	}
+
+	if n.DataAtom == atom.Img {
+		if src := resolveURL(base, attr(n, "src")); src != "" {
+			b.WriteString(`<img src="` + html.EscapeString(src) + `"`)
+			if alt := attr(n, "alt"); alt != "" {
+				b.WriteString(` alt="` + html.EscapeString(alt) + `"`)
+			}
+			b.WriteString(">")
+		}
+		return
+	}
+	if n.DataAtom == atom.Br {
+		b.WriteString("<br>")
+		return
+	}
+
+	b.WriteString("<" + n.Data)
+	if n.DataAtom == atom.A {
+		if href := resolveURL(base, attr(n, "href")); href != "" {
+			b.WriteString(` href="` + html.EscapeString(href) + `"`)
+		}
+	}
+	b.WriteString(">")
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderClean(b, c, base)
+	}
+	b.WriteString("</" + n.Data + ">")
+}
+
+// writeText writes the text of each block element as its own paragraph
+func writeText(b *strings.Builder, n *html.Node) {
+	walk(n, func(c *html.Node) bool {
+		if c.Type != html.ElementNode {
+			return true
+		}
+		switch c.DataAtom {
+		case atom.P, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Li, atom.Pre, atom.Figcaption:
+			if text := collapseSpace(strings.TrimSpace(nodeText(c))); text != "" {
+				b.WriteString(text)
+				b.WriteString("\n\n")
+			}
+			return false
+		case atom.H1:
+			return false
+		}
+		return true
+	})
+}
+
+// parseBaseURL returns pageURL parsed for resolving references, or nil if it is not absolute
+func parseBaseURL(pageURL string) *url.URL {
+	base, err := url.Parse(pageURL)
+	if err != nil || !base.IsAbs() {
+		return nil
+	}
+	return base
+}
+
+func resolveURL(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(strings.ToLower(ref), "javascript:") {
+		return ""
+	}
+	if base == nil {
+		return ref
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(parsed).String()
+}
+
+// walk visits n and its descendants depth first; returning false skips a node's children
+func walk(n *html.Node, visit func(*html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walk(c, visit)
+		c = next
+	}
+}
+
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+		return true
+	})
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func collapseSpace(s string) string {
+	return whitespacePattern.ReplaceAllString(s, " ")
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+)
+
+//go:embed fixtures/article_page.html
+var articlePageHTML string
+
+func TestExtractArticle_FullPage(t *testing.T) {
+	article, err := ExtractArticle(articlePageHTML, "https://vuka.news/news/limpopo-drought")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if article.Title != "Drought forces Limpopo farmers to sell cattle" {
+		t.Errorf("Expected og:title, got '%s'", article.Title)
+	}
+
+	expectedText := []string{
+		"Small-scale farmers in Limpopo",
+		"At an auction outside Polokwane",
+		"We have nothing left to feed them",
+		"Relief scheme delayed",
+		"feed relief scheme in January",
+		"Cattle at a dry dam near Polokwane.",
+	}
+	for _, expected := range expectedText {
+		if !strings.Contains(article.Text, expected) {
+			t.Errorf("Expected text to contain '%s'", expected)
+		}
+	}
+
+	boilerplate := []string{
+		"Vuka News", "Business", "cookies", "Share on", "Subscribe to Vuka Premium",
+		"Water restrictions", "Most read", "Great article", "Copyright", "trackParagraph",
+		"By Thandi Mokoena",
+	}
+	for _, unexpected := range boilerplate {
+		if strings.Contains(article.Text, unexpected) || strings.Contains(article.HTML, unexpected) {
+			t.Errorf("Expected boilerplate '%s' to be removed", unexpected)
+		}
+	}
+
+	if !strings.Contains(article.HTML, `<img src="https://vuka.news/images/limpopo-cattle.jpg" alt="Cattle at a dry dam near Polokwane">`) {
+		t.Errorf("Expected image with resolved source, got %s", article.HTML)
+	}
+	if strings.Contains(article.HTML, "class=") || strings.Contains(article.HTML, "<div") {
+		t.Errorf("Expected attributes and layout tags to be stripped, got %s", article.HTML)
+	}
+	if strings.Contains(article.HTML, "<h1>") {
+		t.Errorf("Expected the headline to be left out of the body")
+	}
+}
+
+func TestExtractArticle_Fragment(t *testing.T) {
+	article, err := ExtractArticle(sampleHTML, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(article.Text, "Make the bosses rich in Cambodia") {
+		t.Errorf("Expected the linked quote to be kept in the text")
+	}
+	if !strings.Contains(article.Text, "Chompey Fong") {
+		t.Errorf("Expected the second paragraph to be kept")
+	}
+	if !strings.Contains(article.HTML, `<a href="https://english.cambodiadaily.com/`) {
+		t.Errorf("Expected inline links to be kept")
+	}
+	if strings.Contains(article.HTML, "style=") {
+		t.Errorf("Expected inline styles to be stripped")
+	}
+}
+
+func TestExtractArticle_NoContent(t *testing.T) {
+	page := `<html><body><nav><a href="/">Home</a></nav><p>Short.</p></body></html>`
+
+	if _, err := ExtractArticle(page, ""); err != ErrNoArticleContent {
+		t.Errorf("Expected ErrNoArticleContent, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// FeedFormat identifies the syndication format a feed was published in
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatRDF  FeedFormat = "rdf"
+	FeedFormatAtom FeedFormat = "atom"
+	FeedFormatJSON FeedFormat = "json"
+)
+
+// ErrUnknownFeedFormat is returned when a document is not a recognised feed
+var ErrUnknownFeedFormat = errors.New("unknown feed format")
+
+// Feed is the normalized representation of any supported feed format.
+// Items are always converted to the RSS Item model so they can flow
+// through Item.ToArticle regardless of where they came from.
+type Feed struct {
+	Format   FeedFormat
+	Title    string
+	Link     string
+	Language string
+	Items    []Item
+}
+
+// ParseFeed sniffs the format of a feed document and normalizes it
+func ParseFeed(body []byte) (*Feed, error) {
+	format, err := DetectFeedFormat(body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FeedFormatJSON:
+		var jsonFeed JSONFeed
+		if err := json.Unmarshal(trimFeedBody(body), &jsonFeed); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON feed: %w", err)
+		}
+		return jsonFeed.ToFeed(), nil
+	case FeedFormatAtom:
+		var atom AtomFeed
+		if err := xml.Unmarshal(body, &atom); err != nil {
+			return nil, fmt.Errorf("failed to parse Atom feed: %w", err)
+		}
+		return atom.ToFeed(), nil
+	case FeedFormatRDF:
+		var rdf RDF
+		if err := xml.Unmarshal(body, &rdf); err != nil {
+			return nil, fmt.Errorf("failed to parse RDF feed: %w", err)
+		}
+		return rdf.ToFeed(), nil
+	default:
+		var rss RSS
+		if err := xml.Unmarshal(body, &rss); err != nil {
+			return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+		}
+		return rss.ToFeed(), nil
+	}
+}
+
+// DetectFeedFormat inspects the document root to work out which parser to use
+func DetectFeedFormat(body []byte) (FeedFormat, error) {
+	trimmed := trimFeedBody(body)
+	if len(trimmed) == 0 {
+		return "", ErrUnknownFeedFormat
+	}
+
+	if trimmed[0] == '{' {
+		return FeedFormatJSON, nil
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", ErrUnknownFeedFormat
+			}
+			return "", fmt.Errorf("%w: %v", ErrUnknownFeedFormat, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(start.Name.Local) {
+		case "rss":
+			return FeedFormatRSS, nil
+		case "feed":
+			return FeedFormatAtom, nil
+		case "rdf":
+			return FeedFormatRDF, nil
+		default:
+			return "", fmt.Errorf("%w: root element <%s>", ErrUnknownFeedFormat, start.Name.Local)
+		}
+	}
+}
+
+// ToFeed normalizes an RSS 2.0 document
+func (rss *RSS) ToFeed() *Feed {
+	return &Feed{
+		Format:   FeedFormatRSS,
+		Title:    rss.Channel.Title,
+		Link:     rss.Channel.Link,
+		Language: rss.Channel.Language,
+		Items:    rss.Channel.Items,
+	}
+}
+
+// trimFeedBody strips a UTF-8 byte order mark and surrounding whitespace
+func trimFeedBody(body []byte) []byte {
+	body = bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))
+	return bytes.TrimSpace(body)
+}
+
+// toRFC1123Z rewrites ISO 8601 timestamps, as used by Atom, RDF and JSON Feed,
+// into the RFC 1123 layout RSS items carry. Unparseable values are returned as-is.
+func toRFC1123Z(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Format(time.RFC1123Z)
+	}
+	return value
+}
@@ -0,0 +1,207 @@
+package models
+
+import (
+	_ "embed"
+	"errors"
+	"testing"
+	"time"
+)
+
+//go:embed fixtures/atom.xml
+var sampleAtom []byte
+
+//go:embed fixtures/feed.json
+var sampleJSONFeed []byte
+
+//go:embed fixtures/rdf.xml
+var sampleRDF []byte
+
+const sampleRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>News24</title>
+    <link>https://www.news24.com/</link>
+    <language>en</language>
+    <item>
+      <title>Rand firms against dollar</title>
+      <link>https://www.news24.com/business/rand-firms</link>
+      <guid>news24-123</guid>
+      <pubDate>Fri, 14 Mar 2025 08:00:00 +0200</pubDate>
+      <category>Markets</category>
+      <content:encoded><![CDATA[<p>The rand firmed.</p>]]></content:encoded>
+    </item>
+  </channel>
+</rss>`
+
+func TestDetectFeedFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     []byte
+		expected FeedFormat
+	}{
+		{name: "RSS 2.0", body: []byte(sampleRSS), expected: FeedFormatRSS},
+		{name: "Atom", body: sampleAtom, expected: FeedFormatAtom},
+		{name: "JSON Feed", body: sampleJSONFeed, expected: FeedFormatJSON},
+		{name: "RSS 1.0 RDF", body: sampleRDF, expected: FeedFormatRDF},
+		{name: "Byte order mark", body: append([]byte("\xef\xbb\xbf"), sampleRSS...), expected: FeedFormatRSS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := DetectFeedFormat(tt.body)
+			if err != nil {
+				t.Fatalf("Did not expect an error but got: %v", err)
+			}
+			if format != tt.expected {
+				t.Errorf("Expected format %s, got %s", tt.expected, format)
+			}
+		})
+	}
+}
+
+func TestDetectFeedFormat_Unknown(t *testing.T) {
+	for _, body := range []string{"", "   ", "<html><body>Not a feed</body></html>"} {
+		if _, err := DetectFeedFormat([]byte(body)); !errors.Is(err, ErrUnknownFeedFormat) {
+			t.Errorf("Expected ErrUnknownFeedFormat for %q, got %v", body, err)
+		}
+	}
+}
+
+func TestParseFeed_RSS(t *testing.T) {
+	feed, err := ParseFeed([]byte(sampleRSS))
+	if err != nil {
+		t.Fatalf("ParseFeed returned an error: %v", err)
+	}
+
+	if feed.Title != "News24" || feed.Language != "en" {
+		t.Errorf("Unexpected channel metadata: %+v", feed)
+	}
+	if len(feed.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(feed.Items))
+	}
+	if feed.Items[0].ContentEncoded != "<p>The rand firmed.</p>" {
+		t.Errorf("Unexpected content: %q", feed.Items[0].ContentEncoded)
+	}
+}
+
+func TestParseFeed_Atom(t *testing.T) {
+	feed, err := ParseFeed(sampleAtom)
+	if err != nil {
+		t.Fatalf("ParseFeed returned an error: %v", err)
+	}
+
+	if feed.Title != "Daily Maverick" {
+		t.Errorf("Expected title 'Daily Maverick', got '%s'", feed.Title)
+	}
+	if feed.Link != "https://www.dailymaverick.co.za/" {
+		t.Errorf("Expected alternate link, got '%s'", feed.Link)
+	}
+	if feed.Language != "en-ZA" {
+		t.Errorf("Expected language 'en-ZA', got '%s'", feed.Language)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(feed.Items))
+	}
+
+	first := feed.Items[0]
+	if first.Link != "https://www.dailymaverick.co.za/article/load-shedding-returns/" {
+		t.Errorf("Expected alternate entry link, got '%s'", first.Link)
+	}
+	if first.GUID != "tag:dailymaverick.co.za,2025:1" {
+		t.Errorf("Unexpected GUID '%s'", first.GUID)
+	}
+	if first.Author != "Ferial Haffajee" {
+		t.Errorf("Unexpected author '%s'", first.Author)
+	}
+	if first.PubDate != "Fri, 14 Mar 2025 08:30:00 +0200" {
+		t.Errorf("Expected published date in RFC1123Z, got '%s'", first.PubDate)
+	}
+	if len(first.Categories) != 2 || first.Categories[0] != "Business" || first.Categories[1] != "energy" {
+		t.Errorf("Unexpected categories %v", first.Categories)
+	}
+	if first.Description != "<p>Stage 4 from tonight.</p>" {
+		t.Errorf("Unexpected summary %q", first.Description)
+	}
+
+	second := feed.Items[1]
+	if second.Link != "https://www.dailymaverick.co.za/article/springboks-squad/" {
+		t.Errorf("Expected link without rel to be used, got '%s'", second.Link)
+	}
+	if second.PubDate != "Thu, 13 Mar 2025 18:00:00 +0000" {
+		t.Errorf("Expected updated date fallback, got '%s'", second.PubDate)
+	}
+	if second.ContentEncoded != `<div xmlns="http://www.w3.org/1999/xhtml"><p>Rassie Erasmus named 36 players.</p></div>` {
+		t.Errorf("Unexpected xhtml content %q", second.ContentEncoded)
+	}
+
+	if _, err := first.ToArticle(feed.Language, first.PublishedAt(time.Now())); err != nil {
+		t.Errorf("Expected Atom item to convert to an article, got: %v", err)
+	}
+}
+
+func TestParseFeed_JSONFeed(t *testing.T) {
+	feed, err := ParseFeed(sampleJSONFeed)
+	if err != nil {
+		t.Fatalf("ParseFeed returned an error: %v", err)
+	}
+
+	if feed.Title != "GroundUp" || feed.Link != "https://www.groundup.org.za/" {
+		t.Errorf("Unexpected feed metadata: %+v", feed)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(feed.Items))
+	}
+
+	first := feed.Items[0]
+	if first.Author != "Nombulelo Damba-Hendrik" {
+		t.Errorf("Unexpected author '%s'", first.Author)
+	}
+	if len(first.Categories) != 2 || first.Categories[0] != "Transport" {
+		t.Errorf("Expected tags as categories, got %v", first.Categories)
+	}
+	if first.PubDate != "Wed, 12 Mar 2025 06:15:00 +0200" {
+		t.Errorf("Unexpected date '%s'", first.PubDate)
+	}
+
+	second := feed.Items[1]
+	if second.Link != "https://www.groundup.org.za/article/water-crisis/" {
+		t.Errorf("Expected external_url fallback, got '%s'", second.Link)
+	}
+	if second.ContentEncoded != "Residents have been without water for a week." {
+		t.Errorf("Expected content_text fallback, got %q", second.ContentEncoded)
+	}
+	if second.Author != "GroundUp Staff" {
+		t.Errorf("Expected JSON Feed 1.0 author, got '%s'", second.Author)
+	}
+}
+
+func TestParseFeed_RDF(t *testing.T) {
+	feed, err := ParseFeed(sampleRDF)
+	if err != nil {
+		t.Fatalf("ParseFeed returned an error: %v", err)
+	}
+
+	if feed.Title != "Nation Africa" || feed.Language != "en-KE" {
+		t.Errorf("Unexpected channel metadata: %+v", feed)
+	}
+	if len(feed.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.GUID != "https://www.example.co.ke/news/budget-2025" {
+		t.Errorf("Expected rdf:about as GUID, got '%s'", item.GUID)
+	}
+	if item.Author != "Jane Wanjiru" {
+		t.Errorf("Unexpected author '%s'", item.Author)
+	}
+	if item.PubDate != "Mon, 10 Mar 2025 14:00:00 +0300" {
+		t.Errorf("Unexpected date '%s'", item.PubDate)
+	}
+	if len(item.Categories) != 2 || item.Categories[1] != "Economy" {
+		t.Errorf("Unexpected subjects %v", item.Categories)
+	}
+	if item.ContentEncoded != "<p>Full budget story.</p>" {
+		t.Errorf("Unexpected content %q", item.ContentEncoded)
+	}
+}
@@ -0,0 +1,266 @@
+package models
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"time"
+	"vuka-api/pkg/models/db"
+)
+
+// OutputFeed is a feed of Vuka articles for partners to syndicate
+type OutputFeed struct {
+	Title       string
+	Description string
+	BaseURL     string // Public URL of the API, used for the feed's links and stored images
+	SelfURL     string // URL the feed is served from
+	Articles    []db.Article
+}
+
+// Updated is when any article in the feed last changed
+func (f OutputFeed) Updated() time.Time {
+	var updated time.Time
+	for _, article := range f.Articles {
+		updated = latest(updated, articleUpdated(article))
+	}
+	return updated
+}
+
+// RSS renders the feed as RSS 2.0 with an atom:link to itself, Media RSS
+// images and each article's original source
+func (f OutputFeed) RSS() ([]byte, error) {
+	channel := rssChannelOut{
+		Title:       f.Title,
+		Link:        f.BaseURL,
+		Description: f.Description,
+		Generator:   "Vuka",
+		SelfLink:    atomLinkOut{Href: f.SelfURL, Rel: "self", Type: "application/rss+xml"},
+	}
+	if updated := f.Updated(); !updated.IsZero() {
+		channel.LastBuildDate = updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, article := range f.Articles {
+		item := rssItemOut{
+			Title:       article.Title,
+			Link:        article.OriginalUrl,
+			Description: article.Summary,
+			GUID:        rssGUIDOut{Value: "urn:uuid:" + article.ID.String(), IsPermaLink: "false"},
+			PubDate:     article.PublishedAt.UTC().Format(time.RFC1123Z),
+		}
+		if article.Source.Name != "" {
+			item.Source = &rssSourceOut{URL: firstNonEmpty(article.Source.RssFeedUrl, article.Source.WebsiteUrl), Name: article.Source.Name}
+		}
+		for _, category := range article.Categories {
+			item.Categories = append(item.Categories, category.Name)
+		}
+		if image := f.mainImage(article); image != nil {
+			item.Media = image
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	return marshalFeed(rssOut{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		MediaNS: "http://search.yahoo.com/mrss/",
+		Channel: channel,
+	})
+}
+
+// Atom renders the feed as Atom 1.0. Entries are attributed to the source
+// that published them, as Atom requires an author.
+func (f OutputFeed) Atom() ([]byte, error) {
+	feed := atomFeedOut{
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		MediaNS: "http://search.yahoo.com/mrss/",
+		ID:      f.SelfURL,
+		Title:   f.Title,
+		Links: []atomLinkOut{
+			{Href: f.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.BaseURL, Rel: "alternate"},
+		},
+		Updated:   formatAtomTime(f.Updated()),
+		Generator: "Vuka",
+		Author:    &atomPersonOut{Name: "Vuka"},
+	}
+	if f.Description != "" {
+		feed.Subtitle = f.Description
+	}
+
+	for _, article := range f.Articles {
+		entry := atomEntryOut{
+			ID:        "urn:uuid:" + article.ID.String(),
+			Title:     article.Title,
+			Links:     []atomLinkOut{{Href: article.OriginalUrl, Rel: "alternate"}},
+			Published: formatAtomTime(article.PublishedAt),
+			Updated:   formatAtomTime(articleUpdated(article)),
+			Author:    &atomPersonOut{Name: firstNonEmpty(article.Source.Name, "Vuka")},
+		}
+		if article.Summary != "" {
+			entry.Summary = &atomTextOut{Type: "html", Body: article.Summary}
+		}
+		for _, category := range article.Categories {
+			entry.Categories = append(entry.Categories, atomCategoryOut{Term: category.Name})
+		}
+		entry.Media = f.mainImage(article)
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return marshalFeed(feed)
+}
+
+// mainImage links to the stored copy of an article's main image when there
+// is one, since publishers' image URLs often block hotlinking
+func (f OutputFeed) mainImage(article db.Article) *mediaContentOut {
+	for _, image := range article.Images {
+		if !image.IsMain {
+			continue
+		}
+		media := &mediaContentOut{URL: image.URL, Medium: "image", Type: image.ContentType}
+		if mediaURL := image.MediaURL(); mediaURL != "" {
+			media.URL = strings.TrimSuffix(f.BaseURL, "/") + mediaURL
+		}
+		if image.Width > 0 && image.Height > 0 {
+			media.Width, media.Height = image.Width, image.Height
+		}
+		return media
+	}
+	return nil
+}
+
+func marshalFeed(feed any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// articleUpdated is when an article was last stored or revised by its publisher
+func articleUpdated(article db.Article) time.Time {
+	updated := latest(article.PublishedAt, article.UpdatedAt)
+	if article.RevisedAt != nil {
+		updated = latest(updated, *article.RevisedAt)
+	}
+	return updated
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func formatAtomTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+type rssOut struct {
+	XMLName xml.Name      `xml:"rss"`
+	Version string        `xml:"version,attr"`
+	AtomNS  string        `xml:"xmlns:atom,attr"`
+	MediaNS string        `xml:"xmlns:media,attr"`
+	Channel rssChannelOut `xml:"channel"`
+}
+
+type rssChannelOut struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Description   string       `xml:"description"`
+	Generator     string       `xml:"generator"`
+	LastBuildDate string       `xml:"lastBuildDate,omitempty"`
+	SelfLink      atomLinkOut  `xml:"atom:link"`
+	Items         []rssItemOut `xml:"item"`
+}
+
+type rssItemOut struct {
+	Title       string           `xml:"title"`
+	Link        string           `xml:"link"`
+	Description string           `xml:"description,omitempty"`
+	GUID        rssGUIDOut       `xml:"guid"`
+	PubDate     string           `xml:"pubDate"`
+	Source      *rssSourceOut    `xml:"source,omitempty"`
+	Categories  []string         `xml:"category"`
+	Media       *mediaContentOut `xml:"media:content,omitempty"`
+}
+
+type rssGUIDOut struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink string `xml:"isPermaLink,attr"`
+}
+
+type rssSourceOut struct {
+	URL  string `xml:"url,attr"`
+	Name string `xml:",chardata"`
+}
+
+type mediaContentOut struct {
+	URL    string `xml:"url,attr"`
+	Medium string `xml:"medium,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Width  int    `xml:"width,attr,omitempty"`
+	Height int    `xml:"height,attr,omitempty"`
+}
+
+type atomFeedOut struct {
+	XMLName   xml.Name       `xml:"feed"`
+	XMLNS     string         `xml:"xmlns,attr"`
+	MediaNS   string         `xml:"xmlns:media,attr"`
+	ID        string         `xml:"id"`
+	Title     string         `xml:"title"`
+	Subtitle  string         `xml:"subtitle,omitempty"`
+	Links     []atomLinkOut  `xml:"link"`
+	Updated   string         `xml:"updated"`
+	Generator string         `xml:"generator"`
+	Author    *atomPersonOut `xml:"author"`
+	Entries   []atomEntryOut `xml:"entry"`
+}
+
+type atomEntryOut struct {
+	ID         string            `xml:"id"`
+	Title      string            `xml:"title"`
+	Links      []atomLinkOut     `xml:"link"`
+	Published  string            `xml:"published"`
+	Updated    string            `xml:"updated"`
+	Author     *atomPersonOut    `xml:"author"`
+	Summary    *atomTextOut      `xml:"summary,omitempty"`
+	Categories []atomCategoryOut `xml:"category"`
+	Media      *mediaContentOut  `xml:"media:content,omitempty"`
+}
+
+type atomLinkOut struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPersonOut struct {
+	Name string `xml:"name"`
+}
+
+type atomTextOut struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomCategoryOut struct {
+	Term string `xml:"term,attr"`
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+func sampleOutputFeed() OutputFeed {
+	published := time.Date(2025, 3, 14, 8, 30, 0, 0, time.UTC)
+	stored := published.Add(time.Minute)
+
+	article := db.Article{
+		Title:       "Eskom suspends load shedding & warns of winter",
+		Summary:     "<p>Stage 4 <em>ends</em> tonight.</p>",
+		OriginalUrl: "https://www.news24.com/eskom-suspends-load-shedding",
+		PublishedAt: published,
+		Source:      db.Source{Name: "News24", WebsiteUrl: "https://www.news24.com"},
+		Categories:  []*db.Category{{Name: "Business"}, {Name: "Politics"}},
+		Images: []db.ArticleImage{
+			{IsMain: true, URL: "https://cdn.news24.com/eskom.jpg", StorageKey: "images/eskom.jpg", StoredAt: &stored},
+		},
+	}
+	article.ID = uuid.MustParse("6f1c7f0e-3f4b-4b43-9a63-2f4bb3f1d9a1")
+	article.UpdatedAt = stored
+	article.Images[0].ID = uuid.MustParse("0b7c3e5a-8d53-4a53-8a1e-5b9c6f4e2d10")
+
+	return OutputFeed{
+		Title:       "Vuka: Latest",
+		Description: "The latest South African news",
+		BaseURL:     "https://api.vuka.com",
+		SelfURL:     "https://api.vuka.com/feeds/latest.xml",
+		Articles:    []db.Article{article},
+	}
+}
+
+func TestOutputFeed_RSS(t *testing.T) {
+	body, err := sampleOutputFeed().RSS()
+	if err != nil {
+		t.Fatalf("RSS returned an error: %v", err)
+	}
+
+	feed, err := ParseFeed(body)
+	if err != nil {
+		t.Fatalf("Expected the RSS output to parse, got: %v\n%s", err, body)
+	}
+	if feed.Format != FeedFormatRSS || feed.Title != "Vuka: Latest" {
+		t.Errorf("Unexpected feed metadata: %+v", feed)
+	}
+	if len(feed.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.Title != "Eskom suspends load shedding & warns of winter" {
+		t.Errorf("Unexpected title %q", item.Title)
+	}
+	if item.Description != "<p>Stage 4 <em>ends</em> tonight.</p>" {
+		t.Errorf("Expected the summary HTML to survive escaping, got %q", item.Description)
+	}
+	if item.PubDate != "Fri, 14 Mar 2025 08:30:00 +0000" {
+		t.Errorf("Unexpected pubDate %q", item.PubDate)
+	}
+	if len(item.Categories) != 2 {
+		t.Errorf("Expected 2 categories, got %v", item.Categories)
+	}
+
+	candidates := item.ImageCandidates()
+	if len(candidates) == 0 || candidates[0].URL != "https://api.vuka.com/media/0b7c3e5a-8d53-4a53-8a1e-5b9c6f4e2d10" {
+		t.Errorf("Expected the stored image to be linked, got %v", candidates)
+	}
+	if !strings.Contains(string(body), `<atom:link href="https://api.vuka.com/feeds/latest.xml" rel="self"`) {
+		t.Errorf("Expected a self link, got\n%s", body)
+	}
+}
+
+func TestOutputFeed_Atom(t *testing.T) {
+	body, err := sampleOutputFeed().Atom()
+	if err != nil {
+		t.Fatalf("Atom returned an error: %v", err)
+	}
+
+	feed, err := ParseFeed(body)
+	if err != nil {
+		t.Fatalf("Expected the Atom output to parse, got: %v\n%s", err, body)
+	}
+	if feed.Format != FeedFormatAtom || len(feed.Items) != 1 {
+		t.Fatalf("Expected an Atom feed with 1 entry, got %+v", feed)
+	}
+
+	item := feed.Items[0]
+	if item.Link != "https://www.news24.com/eskom-suspends-load-shedding" {
+		t.Errorf("Unexpected link %q", item.Link)
+	}
+	if item.Author != "News24" {
+		t.Errorf("Expected the source as author, got %q", item.Author)
+	}
+	if item.Description != "<p>Stage 4 <em>ends</em> tonight.</p>" {
+		t.Errorf("Unexpected summary %q", item.Description)
+	}
+	if !strings.Contains(string(body), "<updated>2025-03-14T08:31:00Z</updated>") {
+		t.Errorf("Expected the feed to be updated when its newest article was, got\n%s", body)
+	}
+}
@@ -0,0 +1,332 @@
+package models
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"vuka-api/pkg/models/db"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxArticleImages caps how many images are stored for an article
+const maxArticleImages = 5
+
+// MediaContent is a Media RSS <media:content> element
+type MediaContent struct {
+	URL         string           `xml:"url,attr"`
+	Type        string           `xml:"type,attr"`
+	Medium      string           `xml:"medium,attr"`
+	Width       string           `xml:"width,attr"`
+	Height      string           `xml:"height,attr"`
+	IsDefault   string           `xml:"isDefault,attr"`
+	Description string           `xml:"http://search.yahoo.com/mrss/ description"`
+	Thumbnails  []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// MediaThumbnail is a Media RSS <media:thumbnail> element
+type MediaThumbnail struct {
+	URL    string `xml:"url,attr"`
+	Width  string `xml:"width,attr"`
+	Height string `xml:"height,attr"`
+}
+
+// MediaGroup is a Media RSS <media:group> holding alternative renditions
+type MediaGroup struct {
+	Contents   []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// Enclosure is an RSS 2.0 <enclosure> element
+type Enclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+// ImageOrigin records where an image candidate was found
+type ImageOrigin string
+
+const (
+	ImageOriginMediaContent   ImageOrigin = "media_content"
+	ImageOriginEnclosure      ImageOrigin = "enclosure"
+	ImageOriginOpenGraph      ImageOrigin = "og_image"
+	ImageOriginDescription    ImageOrigin = "description"
+	ImageOriginContent        ImageOrigin = "content"
+	ImageOriginMediaThumbnail ImageOrigin = "media_thumbnail"
+)
+
+// originScores rank the places an image can come from. Publishers choose
+// media:content, enclosures and og:image as the lead image deliberately,
+// while inline and thumbnail images are often small or incidental.
+var originScores = map[ImageOrigin]int{
+	ImageOriginMediaContent:   50,
+	ImageOriginOpenGraph:      45,
+	ImageOriginEnclosure:      40,
+	ImageOriginDescription:    30,
+	ImageOriginContent:        25,
+	ImageOriginMediaThumbnail: 20,
+}
+
+// unlikelyImageHints mark URLs that are almost never a story's lead image
+var unlikelyImageHints = []string{
+	"logo", "icon", "avatar", "gravatar", "sprite", "placeholder", "spacer",
+	"blank.gif", "emoji", "badge", "button", "/ads/", "advert",
+}
+
+// ImageCandidate is an image found in a feed item or page, before ranking
+type ImageCandidate struct {
+	URL      string
+	AltText  string
+	Width    int
+	Height   int
+	Origin   ImageOrigin
+	Position int // Order within its origin, starting at 0
+}
+
+// Score rates how likely the candidate is to be the article's lead image
+func (c ImageCandidate) Score() int {
+	score := originScores[c.Origin] - 2*c.Position
+
+	switch {
+	case c.Width >= 600:
+		score += 30
+	case c.Width >= 300:
+		score += 15
+	case c.Width > 0 && c.Width < 150:
+		score -= 40
+	}
+	if c.Width > 0 && c.Height > 0 {
+		ratio := float64(c.Width) / float64(c.Height)
+		if ratio > 4 || ratio < 0.25 {
+			score -= 80 // Banners and spacers
+		}
+	}
+
+	lower := strings.ToLower(c.URL)
+	for _, hint := range unlikelyImageHints {
+		if strings.Contains(lower, hint) {
+			score -= 50
+			break
+		}
+	}
+	return score
+}
+
+// RankImages deduplicates candidates by URL, orders them by score and marks the
+// best one as the main image. At most maxArticleImages are returned.
+func RankImages(candidates []ImageCandidate) []db.ArticleImage {
+	best := make(map[string]ImageCandidate)
+	var order []string
+	for _, candidate := range candidates {
+		candidate.URL = strings.TrimSpace(candidate.URL)
+		if !isImageURL(candidate.URL) {
+			continue
+		}
+		existing, seen := best[candidate.URL]
+		if !seen {
+			order = append(order, candidate.URL)
+			best[candidate.URL] = candidate
+			continue
+		}
+		// Keep the higher scoring origin but fill in details the other one knew
+		merged := existing
+		if candidate.Score() > existing.Score() {
+			merged = candidate
+		}
+		if merged.AltText == "" {
+			merged.AltText = existing.AltText + candidate.AltText
+		}
+		if merged.Width == 0 && merged.Height == 0 {
+			merged.Width, merged.Height = max(existing.Width, candidate.Width), max(existing.Height, candidate.Height)
+		}
+		best[candidate.URL] = merged
+	}
+
+	ranked := make([]ImageCandidate, 0, len(order))
+	for _, url := range order {
+		ranked = append(ranked, best[url])
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score() > ranked[j].Score()
+	})
+	if len(ranked) > maxArticleImages {
+		ranked = ranked[:maxArticleImages]
+	}
+
+	images := make([]db.ArticleImage, 0, len(ranked))
+	for i, candidate := range ranked {
+		images = append(images, db.ArticleImage{
+			URL:     candidate.URL,
+			AltText: candidate.AltText,
+			Width:   candidate.Width,
+			Height:  candidate.Height,
+			IsMain:  i == 0,
+		})
+	}
+	return images
+}
+
+// ImageCandidates collects every image the item offers from Media RSS,
+// enclosures and <img> tags in its description and content
+func (feed *Item) ImageCandidates() []ImageCandidate {
+	var candidates []ImageCandidate
+
+	contents := feed.MediaContents
+	thumbnails := feed.MediaThumbnails
+	for _, group := range feed.MediaGroups {
+		contents = append(contents, group.Contents...)
+		thumbnails = append(thumbnails, group.Thumbnails...)
+	}
+
+	for i, content := range contents {
+		if !content.isImage() {
+			continue
+		}
+		candidates = append(candidates, ImageCandidate{
+			URL:      content.URL,
+			AltText:  strings.TrimSpace(content.Description),
+			Width:    atoi(content.Width),
+			Height:   atoi(content.Height),
+			Origin:   ImageOriginMediaContent,
+			Position: i,
+		})
+		thumbnails = append(thumbnails, content.Thumbnails...)
+	}
+
+	for i, enclosure := range feed.Enclosures {
+		if strings.HasPrefix(strings.ToLower(enclosure.Type), "image/") {
+			candidates = append(candidates, ImageCandidate{URL: enclosure.URL, Origin: ImageOriginEnclosure, Position: i})
+		}
+	}
+
+	for i, thumbnail := range thumbnails {
+		candidates = append(candidates, ImageCandidate{
+			URL:      thumbnail.URL,
+			Width:    atoi(thumbnail.Width),
+			Height:   atoi(thumbnail.Height),
+			Origin:   ImageOriginMediaThumbnail,
+			Position: i,
+		})
+	}
+
+	candidates = append(candidates, htmlImageCandidates(feed.Description, ImageOriginDescription)...)
+	candidates = append(candidates, htmlImageCandidates(feed.ContentEncoded, ImageOriginContent)...)
+
+	// Image downloads and clients need absolute URLs
+	base := parseBaseURL(feed.Link)
+	for i := range candidates {
+		candidates[i].URL = absoluteImageURL(base, candidates[i].URL)
+	}
+	return candidates
+}
+
+// absoluteImageURL resolves an image reference against the item's link.
+// Protocol-relative references default to https when the link is unusable.
+func absoluteImageURL(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if base == nil && strings.HasPrefix(ref, "//") {
+		return "https:" + ref
+	}
+	return resolveURL(base, ref)
+}
+
+// ExtractPageImage returns the og:image, or failing that twitter:image, of an
+// HTML page resolved against pageURL
+func ExtractPageImage(pageHTML, pageURL string) string {
+	doc, err := html.Parse(strings.NewReader(pageHTML))
+	if err != nil {
+		return ""
+	}
+	return pageImage(doc, pageURL)
+}
+
+func pageImage(doc *html.Node, pageURL string) string {
+	var ogImage, twitterImage string
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if n.DataAtom == atom.Body {
+			return false // Meta tags live in the head
+		}
+		if n.DataAtom != atom.Meta {
+			return true
+		}
+		key := attr(n, "property")
+		if key == "" {
+			key = attr(n, "name")
+		}
+		switch key {
+		case "og:image", "og:image:url", "og:image:secure_url":
+			if ogImage == "" {
+				ogImage = attr(n, "content")
+			}
+		case "twitter:image", "twitter:image:src":
+			if twitterImage == "" {
+				twitterImage = attr(n, "content")
+			}
+		}
+		return true
+	})
+
+	image := ogImage
+	if image == "" {
+		image = twitterImage
+	}
+	image = resolveURL(parseBaseURL(pageURL), image)
+	if !isImageURL(image) {
+		return ""
+	}
+	return image
+}
+
+func (c MediaContent) isImage() bool {
+	if c.Medium != "" {
+		return c.Medium == "image"
+	}
+	if c.Type != "" {
+		return strings.HasPrefix(strings.ToLower(c.Type), "image/")
+	}
+	return true // Neither attribute is required; most untyped media:content is an image
+}
+
+func htmlImageCandidates(htmlContent string, origin ImageOrigin) []ImageCandidate {
+	if htmlContent == "" {
+		return nil
+	}
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	var candidates []ImageCandidate
+	walk(doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Img && !isTrackingPixel(n) {
+			if src := attr(n, "src"); src != "" {
+				candidates = append(candidates, ImageCandidate{
+					URL:      src,
+					AltText:  attr(n, "alt"),
+					Width:    atoi(attr(n, "width")),
+					Height:   atoi(attr(n, "height")),
+					Origin:   origin,
+					Position: len(candidates),
+				})
+			}
+		}
+		return true
+	})
+	return candidates
+}
+
+func isImageURL(raw string) bool {
+	lower := strings.ToLower(raw)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(s, "px")))
+	return n
+}
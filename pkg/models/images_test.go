@@ -0,0 +1,320 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+//go:embed fixtures/media_rss.xml
+var sampleMediaRSS []byte
+
+func TestItemToArticle_RanksFeedImages(t *testing.T) {
+	feed, err := ParseFeed(sampleMediaRSS)
+	if err != nil {
+		t.Fatalf("ParseFeed returned an error: %v", err)
+	}
+	if len(feed.Items) != 4 {
+		t.Fatalf("Expected 4 items, got %d", len(feed.Items))
+	}
+
+	tests := []struct {
+		name       string
+		item       Item
+		mainURL    string
+		mainAlt    string
+		mainWidth  int
+		imageCount int
+	}{
+		{
+			name:       "Large media:content beats thumbnail and logo",
+			item:       feed.Items[0],
+			mainURL:    "https://cdn.ewn.co.za/budget-vote.jpg",
+			mainAlt:    "MPs in the National Assembly",
+			mainWidth:  1200,
+			imageCount: 3,
+		},
+		{
+			name:       "Largest image in media:group, video skipped",
+			item:       feed.Items[1],
+			mainURL:    "https://cdn.ewn.co.za/storm-large.jpg",
+			mainWidth:  1280,
+			imageCount: 2,
+		},
+		{
+			name:       "Image enclosure used, audio and tracking pixel skipped",
+			item:       feed.Items[2],
+			mainURL:    "https://cdn.ewn.co.za/eskom.jpg",
+			imageCount: 1,
+		},
+		{
+			name:       "No images",
+			item:       feed.Items[3],
+			imageCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := tt.item.ToArticle(feed.Language, PubDate{})
+			if err != nil {
+				t.Fatalf("ToArticle returned an error: %v", err)
+			}
+			if len(article.Images) != tt.imageCount {
+				t.Fatalf("Expected %d images, got %d: %+v", tt.imageCount, len(article.Images), article.Images)
+			}
+			if tt.imageCount == 0 {
+				return
+			}
+
+			main := article.Images[0]
+			if !main.IsMain {
+				t.Errorf("Expected first image to be main")
+			}
+			for _, image := range article.Images[1:] {
+				if image.IsMain {
+					t.Errorf("Expected only one main image, %s is also main", image.URL)
+				}
+			}
+			if main.URL != tt.mainURL {
+				t.Errorf("Expected main image %s, got %s", tt.mainURL, main.URL)
+			}
+			if main.AltText != tt.mainAlt {
+				t.Errorf("Expected alt text %q, got %q", tt.mainAlt, main.AltText)
+			}
+			if main.Width != tt.mainWidth {
+				t.Errorf("Expected width %d, got %d", tt.mainWidth, main.Width)
+			}
+		})
+	}
+}
+
+func TestRankImages(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []ImageCandidate
+		expected   []string
+	}{
+		{
+			name: "Duplicates merged keeping the better origin",
+			candidates: []ImageCandidate{
+				{URL: "https://example.com/a.jpg", Origin: ImageOriginMediaThumbnail},
+				{URL: "https://example.com/b.jpg", Origin: ImageOriginDescription},
+				{URL: "https://example.com/a.jpg", Origin: ImageOriginMediaContent},
+			},
+			expected: []string{"https://example.com/a.jpg", "https://example.com/b.jpg"},
+		},
+		{
+			name: "Earlier inline images rank higher",
+			candidates: []ImageCandidate{
+				{URL: "https://example.com/first.jpg", Origin: ImageOriginContent, Position: 0},
+				{URL: "https://example.com/second.jpg", Origin: ImageOriginContent, Position: 1},
+			},
+			expected: []string{"https://example.com/first.jpg", "https://example.com/second.jpg"},
+		},
+		{
+			name: "Banners and icons rank last",
+			candidates: []ImageCandidate{
+				{URL: "https://example.com/banner.jpg", Origin: ImageOriginMediaContent, Width: 1200, Height: 100},
+				{URL: "https://example.com/icons/share.png", Origin: ImageOriginMediaContent},
+				{URL: "https://example.com/photo.jpg", Origin: ImageOriginContent, Position: 3},
+			},
+			expected: []string{"https://example.com/photo.jpg", "https://example.com/banner.jpg", "https://example.com/icons/share.png"},
+		},
+		{
+			name: "Relative and data URLs skipped",
+			candidates: []ImageCandidate{
+				{URL: "/images/photo.jpg", Origin: ImageOriginMediaContent},
+				{URL: "data:image/gif;base64,R0lGOD", Origin: ImageOriginDescription},
+				{URL: " https://example.com/photo.jpg ", Origin: ImageOriginDescription},
+			},
+			expected: []string{"https://example.com/photo.jpg"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := RankImages(tt.candidates)
+			if len(images) != len(tt.expected) {
+				t.Fatalf("Expected %d images, got %d", len(tt.expected), len(images))
+			}
+			for i, url := range tt.expected {
+				if images[i].URL != url {
+					t.Errorf("Image %d: expected %s, got %s", i, url, images[i].URL)
+				}
+				if images[i].IsMain != (i == 0) {
+					t.Errorf("Image %d: expected IsMain %t, got %t", i, i == 0, images[i].IsMain)
+				}
+			}
+		})
+	}
+}
+
+func TestRankImages_CapsImageCount(t *testing.T) {
+	var candidates []ImageCandidate
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		candidates = append(candidates, ImageCandidate{URL: "https://example.com/" + name + ".jpg", Origin: ImageOriginContent})
+	}
+	if images := RankImages(candidates); len(images) != maxArticleImages {
+		t.Errorf("Expected %d images, got %d", maxArticleImages, len(images))
+	}
+}
+
+func TestFeedImagesFromAtomAndJSONFeed(t *testing.T) {
+	atom := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <title>Channel</title>
+  <entry>
+    <id>yt:video:1</id>
+    <title>Video</title>
+    <link rel="alternate" href="https://example.com/watch/1"/>
+    <link rel="enclosure" type="image/png" href="https://example.com/enclosure.png"/>
+    <updated>2025-03-14T10:00:00Z</updated>
+    <media:group>
+      <media:thumbnail url="https://example.com/hq.jpg" width="480" height="360"/>
+    </media:group>
+  </entry>
+</feed>`)
+	feed, err := ParseFeed(atom)
+	if err != nil {
+		t.Fatalf("ParseFeed returned an error: %v", err)
+	}
+	article, _ := feed.Items[0].ToArticle("", PubDate{})
+	if len(article.Images) != 2 || article.Images[0].URL != "https://example.com/enclosure.png" {
+		t.Errorf("Expected enclosure then thumbnail, got %+v", article.Images)
+	}
+
+	jsonFeed := []byte(`{"version": "https://jsonfeed.org/version/1.1", "title": "Feed", "items": [
+		{"id": "1", "url": "https://example.com/1", "title": "One", "content_html": "<p>Hi</p>",
+		 "image": "https://example.com/main.jpg", "banner_image": "https://example.com/banner.jpg"}]}`)
+	feed, err = ParseFeed(jsonFeed)
+	if err != nil {
+		t.Fatalf("ParseFeed returned an error: %v", err)
+	}
+	article, _ = feed.Items[0].ToArticle("", PubDate{})
+	if len(article.Images) != 2 || article.Images[0].URL != "https://example.com/main.jpg" {
+		t.Errorf("Expected image then banner_image, got %+v", article.Images)
+	}
+}
+
+func TestExtractPageImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     string
+		expected string
+	}{
+		{
+			name:     "og:image",
+			page:     `<html><head><meta name="twitter:image" content="https://example.com/tw.jpg"><meta property="og:image" content="https://example.com/og.jpg"></head><body></body></html>`,
+			expected: "https://example.com/og.jpg",
+		},
+		{
+			name:     "twitter:image fallback",
+			page:     `<html><head><meta name="twitter:image" content="https://example.com/tw.jpg"></head></html>`,
+			expected: "https://example.com/tw.jpg",
+		},
+		{
+			name:     "Relative URL resolved",
+			page:     `<html><head><meta property="og:image" content="/img/lead.jpg"></head></html>`,
+			expected: "https://news.example.com/img/lead.jpg",
+		},
+		{
+			name:     "Meta tags in body ignored",
+			page:     `<html><head></head><body><meta property="og:image" content="https://example.com/og.jpg"></body></html>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractPageImage(tt.page, "https://news.example.com/story/1"); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestItemImageCandidates_ResolvesURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		src      string
+		expected string
+	}{
+		{name: "Protocol-relative takes the link's scheme", link: "http://www.citizen.co.za/news/1", src: "//cdn.citizen.co.za/a.jpg", expected: "http://cdn.citizen.co.za/a.jpg"},
+		{name: "Protocol-relative without a link", link: "", src: "//cdn.citizen.co.za/a.jpg", expected: "https://cdn.citizen.co.za/a.jpg"},
+		{name: "Root-relative", link: "https://www.citizen.co.za/news/1", src: "/images/a.jpg", expected: "https://www.citizen.co.za/images/a.jpg"},
+		{name: "Relative without a link is dropped", link: "", src: "images/a.jpg", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := Item{Link: tt.link, Description: `<img src="` + tt.src + `" width="800" height="450">`}
+			images := RankImages(item.ImageCandidates())
+			if tt.expected == "" {
+				if len(images) != 0 {
+					t.Errorf("Expected no images, got %+v", images)
+				}
+				return
+			}
+			if len(images) != 1 || images[0].URL != tt.expected {
+				t.Errorf("Expected %s, got %+v", tt.expected, images)
+			}
+		})
+	}
+}
+
+func TestArticleImage_JSONLinksStoredCopies(t *testing.T) {
+	id := uuid.MustParse("0b7c3e5a-8d53-4a53-8a1e-5b9c6f4e2d10")
+	stored := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		image    db.ArticleImage
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "Not stored yet",
+			image:    db.ArticleImage{URL: "https://cdn.news24.com/eskom.jpg"},
+			expected: []string{`"url":"https://cdn.news24.com/eskom.jpg"`},
+			absent:   []string{"mediaUrl", "thumbnailUrl"},
+		},
+		{
+			name:     "Stored without a thumbnail",
+			image:    db.ArticleImage{URL: "https://cdn.news24.com/eskom.jpg", StorageKey: "images/eskom.jpg", StoredAt: &stored},
+			expected: []string{`"mediaUrl":"/media/` + id.String() + `"`},
+			absent:   []string{"thumbnailUrl", "images/eskom.jpg"},
+		},
+		{
+			name:     "Stored with a thumbnail",
+			image:    db.ArticleImage{URL: "https://cdn.news24.com/eskom.jpg", StorageKey: "images/eskom.jpg", ThumbnailKey: "images/eskom_thumb.jpg", StoredAt: &stored},
+			expected: []string{`"mediaUrl":"/media/` + id.String() + `"`, `"thumbnailUrl":"/media/` + id.String() + `?size=thumb"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.image.ID = id
+			body, err := json.Marshal([]db.ArticleImage{tt.image})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("Expected %s in %s", want, body)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(string(body), unwanted) {
+					t.Errorf("Expected no %s in %s", unwanted, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package ingestion
+
+import "time"
+
+// Backoff returns how long to wait before fetching a source again after the
+// given number of consecutive failures. The delay doubles from base and is
+// capped at max.
+func Backoff(failures int, base, max time.Duration) time.Duration {
+	if failures < 1 || base <= 0 {
+		return 0
+	}
+
+	delay := base
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+
+	if delay > max {
+		return max
+	}
+	return delay
+}
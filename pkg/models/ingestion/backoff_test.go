@@ -0,0 +1,30 @@
+package ingestion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		expected time.Duration
+	}{
+		{"No failures", 0, 0},
+		{"First failure waits base", 1, time.Hour},
+		{"Second failure doubles", 2, 2 * time.Hour},
+		{"Fourth failure", 4, 8 * time.Hour},
+		{"Capped at max", 6, 24 * time.Hour},
+		{"Many failures stay capped", 50, 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Backoff(tt.failures, time.Hour, 24*time.Hour)
+			if got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
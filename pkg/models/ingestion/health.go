@@ -0,0 +1,33 @@
+package ingestion
+
+import (
+	"time"
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+// Health states reported for a source
+const (
+	HealthHealthy      = "healthy"
+	HealthFailing      = "failing"
+	HealthNeverFetched = "never_fetched"
+	HealthDisabled     = "disabled"
+)
+
+// SourceHealth summarises recent ingestion attempts for a single source
+type SourceHealth struct {
+	SourceID            uuid.UUID             `json:"sourceId"`
+	SourceName          string                `json:"sourceName"`
+	FeedURL             string                `json:"feedUrl"`
+	Status              string                `json:"status"`
+	ConsecutiveFailures int                   `json:"consecutiveFailures"`
+	SuccessRate         float64               `json:"successRate"`
+	LastAttemptAt       *time.Time            `json:"lastAttemptAt"`
+	LastSuccessAt       *time.Time            `json:"lastSuccessAt"`
+	LastError           string                `json:"lastError,omitempty"`
+	NextFetchAt         *time.Time            `json:"nextFetchAt"`
+	DisabledAt          *time.Time            `json:"disabledAt"`
+	DisabledReason      string                `json:"disabledReason,omitempty"`
+	RecentAttempts      []db.IngestionAttempt `json:"recentAttempts"`
+}
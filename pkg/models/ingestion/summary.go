@@ -0,0 +1,88 @@
+package ingestion
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the outcome of ingesting a single source
+type Status string
+
+const (
+	StatusSuccess     Status = "success"
+	StatusNotModified Status = "not_modified"
+	StatusFailed      Status = "failed"
+	StatusSkipped     Status = "skipped"
+)
+
+// Trigger records what started an ingestion run
+type Trigger string
+
+const (
+	TriggerScheduled Trigger = "scheduled"
+	TriggerManual    Trigger = "manual"
+)
+
+// FeedResult counts what happened to the items of one fetched feed
+type FeedResult struct {
+	StatusCode     int  `json:"statusCode"`
+	NotModified    bool `json:"notModified"`
+	ItemsSeen      int  `json:"itemsSeen"`
+	ItemsNew       int  `json:"itemsNew"`
+	ItemsDuplicate int  `json:"itemsDuplicate"`
+	ItemsUpdated   int  `json:"itemsUpdated"`
+	ItemsFailed    int  `json:"itemsFailed"`
+	DatesRescued   int  `json:"datesRescued"`
+}
+
+// SourceResult is the outcome of ingesting one source during a run
+type SourceResult struct {
+	SourceID   uuid.UUID `json:"sourceId"`
+	SourceName string    `json:"sourceName"`
+	FeedURL    string    `json:"feedUrl"`
+	Status     Status    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+	FeedResult
+}
+
+// RunSummary aggregates the results of an ingestion run across all sources
+type RunSummary struct {
+	RunID          *uuid.UUID     `json:"runId"`
+	Trigger        Trigger        `json:"trigger"`
+	StartedAt      time.Time      `json:"startedAt"`
+	FinishedAt     time.Time      `json:"finishedAt"`
+	DurationMs     int64          `json:"durationMs"`
+	SourcesTotal   int            `json:"sourcesTotal"`
+	Succeeded      int            `json:"succeeded"`
+	NotModified    int            `json:"notModified"`
+	Failed         int            `json:"failed"`
+	Skipped        int            `json:"skipped"`
+	ItemsNew       int            `json:"itemsNew"`
+	ItemsDuplicate int            `json:"itemsDuplicate"`
+	ItemsUpdated   int            `json:"itemsUpdated"`
+	ItemsFailed    int            `json:"itemsFailed"`
+	Results        []SourceResult `json:"results"`
+}
+
+// Add folds a source result into the summary totals
+func (s *RunSummary) Add(result SourceResult) {
+	s.SourcesTotal++
+	switch result.Status {
+	case StatusSuccess:
+		s.Succeeded++
+	case StatusNotModified:
+		s.NotModified++
+	case StatusFailed:
+		s.Failed++
+	case StatusSkipped:
+		s.Skipped++
+	}
+	s.ItemsNew += result.ItemsNew
+	s.ItemsDuplicate += result.ItemsDuplicate
+	s.ItemsUpdated += result.ItemsUpdated
+	s.ItemsFailed += result.ItemsFailed
+	s.Results = append(s.Results, result)
+}
@@ -0,0 +1,97 @@
+package models
+
+// The root of a JSON Feed 1.0/1.1 document (https://jsonfeed.org/version/1.1)
+type JSONFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Language    string         `json:"language"`
+	Items       []JSONFeedItem `json:"items"`
+}
+
+// A JSON Feed item represents a single article or post
+type JSONFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []JSONFeedAuthor `json:"authors"`
+	Author        *JSONFeedAuthor  `json:"author"` // JSON Feed 1.0
+	Tags          []string         `json:"tags"`
+	Image         string           `json:"image"`
+	BannerImage   string           `json:"banner_image"`
+}
+
+// JSONFeedAuthor describes an item author
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ToFeed normalizes a JSON Feed into the shared Feed model
+func (f *JSONFeed) ToFeed() *Feed {
+	feed := &Feed{
+		Format:   FeedFormatJSON,
+		Title:    f.Title,
+		Link:     f.HomePageURL,
+		Language: f.Language,
+		Items:    make([]Item, 0, len(f.Items)),
+	}
+
+	for _, item := range f.Items {
+		feed.Items = append(feed.Items, item.ToItem())
+	}
+
+	return feed
+}
+
+// ToItem converts a JSON Feed item into an RSS item
+func (i *JSONFeedItem) ToItem() Item {
+	link := i.URL
+	if link == "" {
+		link = i.ExternalURL
+	}
+
+	pubDate := i.DatePublished
+	if pubDate == "" {
+		pubDate = i.DateModified
+	}
+
+	content := i.ContentHTML
+	if content == "" {
+		content = i.ContentText
+	}
+
+	var author string
+	if len(i.Authors) > 0 {
+		author = i.Authors[0].Name
+	} else if i.Author != nil {
+		author = i.Author.Name
+	}
+
+	// The item's image is its main image, so treat it like media:content
+	var media []MediaContent
+	for _, image := range []string{i.Image, i.BannerImage} {
+		if image != "" {
+			media = append(media, MediaContent{URL: image, Medium: "image"})
+		}
+	}
+
+	return Item{
+		Title:          i.Title,
+		Link:           link,
+		Description:    i.Summary,
+		GUID:           i.ID,
+		Author:         author,
+		PubDate:        toRFC1123Z(pubDate),
+		Categories:     i.Tags,
+		ContentEncoded: content,
+		MediaContents:  media,
+	}
+}
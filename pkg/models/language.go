@@ -0,0 +1,119 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LanguageConfidenceThreshold is the confidence above which a detected
+// language is preferred over the language the feed declares
+const LanguageConfidenceThreshold = 0.5
+
+// minLanguageEvidence is the score at which a detection is fully trusted;
+// shorter texts get proportionally lower confidence
+const minLanguageEvidence = 3.0
+
+// languageProfile lists the words and word beginnings typical of a language
+type languageProfile struct {
+	code     string
+	words    map[string]bool
+	prefixes []string // Noun class and verb prefixes, only counted on longer words
+}
+
+// languageProfiles cover the languages South African feeds publish in. The
+// words are the most frequent function words of news text in each language.
+var languageProfiles = []languageProfile{
+	{
+		code: "en",
+		words: wordSet("the and of to in is that for on with was as by at from has have are be " +
+			"will said this after not he she they their who his her were been but its an which " +
+			"would about more than over new also into could"),
+	},
+	{
+		code: "af",
+		words: wordSet("die en van is het nie in op vir met om te wat sy hy ook maar na se sal " +
+			"was deur ons hulle word kan aan uit oor dat nog baie volgens jaar gesê twee nuwe " +
+			"tussen daar moet sê weer toe elke ná"),
+	},
+	{
+		code: "zu",
+		words: wordSet("ukuthi futhi kodwa uma lapho kanye ngoba kusho abantu kule noma nje " +
+			"wathi bathi kakhulu lokhu ngokusho ngesikhathi kanti kuze ngaphansi phezu " +
+			"izwe ukuze yini manje kubo lo le kwi kulo lezi lesi leli kungani ngenxa " +
+			"umphakathi uhulumeni amaphoyisa emva ngemuva"),
+		prefixes: []string{"uku", "aba", "ama", "isi", "izi", "nge", "nga", "kwa", "ezi", "olu", "ngo"},
+	},
+	{
+		code: "xh",
+		words: wordSet("ukuba kwaye kodwa xa ukuze ngoko ngexesha oku ke esi eli kuba kakhulu " +
+			"abantu kule kwakhona ngokutsho uthe bathi ngenxa emva phantsi phezu ilizwe " +
+			"nje kananjalo noko ezi olu ngoku urhulumente amapolisa"),
+		prefixes: []string{"uku", "aba", "ama", "isi", "izi", "nge", "nga", "kwa", "ezi", "olu", "ngo"},
+	},
+	{
+		code: "st",
+		words: wordSet("le ya ho ka ba sa wa tse hore hobane empa mme bona moo ha ke hona " +
+			"feela kapa bohle ntho motho batho naheng mmuso sepolesa kamora pele " +
+			"haholo ntse hape ene eng"),
+	},
+	{
+		code: "tn",
+		words: wordSet("le go ya ka mo ba ga e gore mme fa jaaka bone sa tse ne gape thata " +
+			"fela kgotsa batho motho puso mapodise morago pele naga eng jalo"),
+	},
+}
+
+// DetectLanguage guesses the ISO 639-1 language of a short text such as a
+// headline and summary. It works offline from word lists, so it is meant for
+// the languages in languageProfiles only. The confidence is between 0 and 1;
+// an empty code means no language could be recognised.
+func DetectLanguage(text string) (string, float64) {
+	words := strings.FieldsFunc(strings.ToLower(htmlTagPattern.ReplaceAllString(text, " ")), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) == 0 {
+		return "", 0
+	}
+
+	scores := make([]float64, len(languageProfiles))
+	total := 0.0
+	for i, profile := range languageProfiles {
+		for _, word := range words {
+			if profile.words[word] {
+				scores[i]++
+				continue
+			}
+			if len(word) >= 6 {
+				for _, prefix := range profile.prefixes {
+					if strings.HasPrefix(word, prefix) {
+						scores[i] += 0.5
+						break
+					}
+				}
+			}
+		}
+		total += scores[i]
+	}
+	if total == 0 {
+		return "", 0
+	}
+
+	best := 0
+	for i, score := range scores {
+		if score > scores[best] {
+			best = i
+		}
+	}
+
+	// How much the winner stands out, scaled down when there is little evidence
+	confidence := scores[best] / total * min(1, scores[best]/minLanguageEvidence)
+	return languageProfiles[best].code, confidence
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,83 @@
+package models
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "English",
+			text:     "Eskom says load shedding will be suspended for the weekend after units return to service",
+			expected: "en",
+		},
+		{
+			name:     "Afrikaans",
+			text:     "Die minister het gesê dat beurtkrag nie weer hierdie week sal plaasvind nie",
+			expected: "af",
+		},
+		{
+			name:     "isiZulu",
+			text:     "Amaphoyisa athi abantu abathathu baboshiwe ngemuva kokuthi kuqhume udlame lapho emphakathini",
+			expected: "zu",
+		},
+		{
+			name:     "isiXhosa",
+			text:     "Amapolisa athi abantu abathathu babanjiwe kwaye uphando luyaqhubeka ngokutsho kukaphathiswa",
+			expected: "xh",
+		},
+		{
+			name:     "Sesotho",
+			text:     "Sepolesa le re batho ba bararo ba tshwerwe hobane ba ne ba utswa koloi ya mmuso",
+			expected: "st",
+		},
+		{
+			name:     "Setswana",
+			text:     "Mapodise a re batho ba le bararo ba tshwerwe gore ba ne ba utswa koloi ya puso",
+			expected: "tn",
+		},
+		{
+			name:     "HTML is ignored",
+			text:     "<p>The <strong>president</strong> said that the budget will be tabled on Wednesday</p>",
+			expected: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, confidence := DetectLanguage(tt.text)
+			if code != tt.expected {
+				t.Errorf("Expected %s, got %s (confidence %.2f)", tt.expected, code, confidence)
+			}
+			if confidence <= 0.5 || confidence > 1 {
+				t.Errorf("Expected confidence above 0.5, got %.2f", confidence)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage_LowEvidence(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "Empty", text: ""},
+		{name: "Numbers only", text: "2024 - 15:30"},
+		{name: "Proper nouns", text: "Ramaphosa Zuma Malema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, confidence := DetectLanguage(tt.text); code != "" || confidence != 0 {
+				t.Errorf("Expected no language, got %s (confidence %.2f)", code, confidence)
+			}
+		})
+	}
+
+	// A single function word is not enough to be sure
+	if _, confidence := DetectLanguage("Springboks in Paris"); confidence >= 0.5 {
+		t.Errorf("Expected low confidence for one matching word, got %.2f", confidence)
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import "encoding/xml"
+
+// The root of an RSS 1.0 (RDF) feed. Unlike RSS 2.0, items are siblings of
+// the channel rather than children of it.
+type RDF struct {
+	XMLName xml.Name   `xml:"RDF"`
+	Channel RDFChannel `xml:"channel"`
+	Items   []RDFItem  `xml:"item"`
+}
+
+// The channel element containing RSS 1.0 feed metadata
+type RDFChannel struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Language    string `xml:"http://purl.org/dc/elements/1.1/ language"`
+}
+
+// An RSS 1.0 item; dates, authors and subjects come from Dublin Core
+type RDFItem struct {
+	About          string   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title          string   `xml:"title"`
+	Link           string   `xml:"link"`
+	Description    string   `xml:"description"`
+	Creator        string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Date           string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Subjects       []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+	ContentEncoded string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+// ToFeed normalizes an RDF feed into the shared Feed model
+func (r *RDF) ToFeed() *Feed {
+	feed := &Feed{
+		Format:   FeedFormatRDF,
+		Title:    r.Channel.Title,
+		Link:     r.Channel.Link,
+		Language: r.Channel.Language,
+		Items:    make([]Item, 0, len(r.Items)),
+	}
+
+	for _, item := range r.Items {
+		guid := item.About
+		if guid == "" {
+			guid = item.Link
+		}
+		feed.Items = append(feed.Items, Item{
+			Title:          item.Title,
+			Link:           item.Link,
+			Description:    item.Description,
+			GUID:           guid,
+			Author:         item.Creator,
+			PubDate:        toRFC1123Z(item.Date),
+			Categories:     item.Subjects,
+			ContentEncoded: item.ContentEncoded,
+		})
+	}
+
+	return feed
+}
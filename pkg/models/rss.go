@@ -6,8 +6,6 @@ import (
 	"strings"
 	"time"
 	"vuka-api/pkg/models/db"
-
-	"golang.org/x/net/html"
 )
 
 // The root of the RSS feed
@@ -34,80 +32,55 @@ type Item struct {
 	Description    string   `xml:"description"`
 	GUID           string   `xml:"guid"`
 	Author         string   `xml:"author"`
-	PubDate        string   `xml:"pubDate"` // You might want to parse this into a time.Time later
+	PubDate        string   `xml:"pubDate"` // Resolved with ParsePubDate
 	Categories     []string `xml:"category"`
 	ContentEncoded string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
-}
 
-func (feed *Item) ToArticle(language string) (*db.Article, error) {
-	publishedAt, err := time.Parse(time.RFC1123Z, feed.PubDate)
-	if err != nil {
-		return nil, err
-	}
+	// Images declared outside the HTML, ranked together with inline images by ImageCandidates
+	MediaContents   []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []MediaGroup     `xml:"http://search.yahoo.com/mrss/ group"`
+	Enclosures      []Enclosure      `xml:"enclosure"`
+}
 
-	images, _ := extractImagesFromHTML(feed.Description)
+// PublishedAt resolves the item's pubDate, falling back to fetchedAt
+func (feed *Item) PublishedAt(fetchedAt time.Time) PubDate {
+	return ParsePubDate(feed.PubDate, fetchedAt)
+}
 
-	// If no images found in description, extract from content body (take first 3)
-	if len(images) == 0 && feed.ContentEncoded != "" {
-		contentImages, _ := extractImagesFromHTML(feed.ContentEncoded)
-		if len(contentImages) > 3 {
-			images = contentImages[:3]
-		} else {
-			images = contentImages
-		}
-	}
+func (feed *Item) ToArticle(language string, pubDate PubDate) (*db.Article, error) {
+	images := RankImages(feed.ImageCandidates())
 
 	// Remove img tags from description
 	re := regexp.MustCompile(`<img[^>]*>`)
-	summary := re.ReplaceAllString(feed.Description, "")
+	rawSummary := re.ReplaceAllString(feed.Description, "")
+
+	summary := SanitizeSummaryHTML(feed.Description)
+	content := SanitizeHTML(feed.ContentEncoded)
+	summaryText := HTMLToText(summary)
+	detectedLanguage, languageConfidence := DetectLanguage(feed.Title + "\n" + summaryText)
 
 	article := &db.Article{
-		Title:       feed.Title,
-		Language:    language,
-		OriginalUrl: feed.Link,
-		Summary:     summary, // Use cleaned summary
-		ContentBody: feed.ContentEncoded,
-		PublishedAt: publishedAt,
-		IsFeatured:  false,
-		Images:      images,
+		Title:                feed.Title,
+		Language:             language,
+		DetectedLanguage:     detectedLanguage,
+		LanguageConfidence:   languageConfidence,
+		OriginalUrl:          feed.Link,
+		CanonicalUrl:         CanonicalURL(feed.Link),
+		GUID:                 strings.TrimSpace(feed.GUID),
+		Summary:              summary,
+		SummaryText:          summaryText,
+		ContentBody:          content,
+		ContentText:          HTMLToText(content),
+		PublishedAt:          pubDate.Time,
+		IsFeatured:           false,
+		Images:               images,
+		PublishedAtEstimated: pubDate.Estimated,
+		SimHash:              int64(SimHash(feed.Title + " " + summary)),
+		// Hash the feed's values before sanitization so that tightening the
+		// sanitizer never registers as an edit by the publisher
+		ContentHash: ArticleContentHash(feed.Title, rawSummary, feed.ContentEncoded),
 	}
 
 	return article, nil
 }
-
-func extractImagesFromHTML(htmlContent string) ([]db.ArticleImage, error) {
-	var images []db.ArticleImage
-	doc, err := html.Parse(strings.NewReader(htmlContent))
-	if err != nil {
-		return nil, err
-	}
-
-	var f func(*html.Node)
-	f = func(n *html.Node) {
-		if n.Type == html.ElementNode && n.Data == "img" {
-			var src, alt string
-			for _, a := range n.Attr {
-				if a.Key == "src" {
-					src = a.Val
-				}
-				if a.Key == "alt" {
-					alt = a.Val
-				}
-			}
-			if src != "" {
-				isMain := len(images) == 0
-				images = append(images, db.ArticleImage{
-					URL:     src,
-					AltText: alt,
-					IsMain:  isMain,
-				})
-			}
-		}
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			f(c)
-		}
-	}
-	f(doc)
-
-	return images, nil
-}
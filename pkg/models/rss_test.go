@@ -9,13 +9,12 @@ import (
 //go:embed fixtures/sample.html
 var sampleHTML string
 
-func TestExtractImagesFromHTML(t *testing.T) {
+func TestRankImages_HTMLImages(t *testing.T) {
 	tests := []struct {
 		name           string
 		htmlContent    string
 		expectedCount  int
 		expectedImages []db.ArticleImage
-		expectError    bool
 	}{
 		{
 			name: "Single image with alt text",
@@ -31,7 +30,6 @@ func TestExtractImagesFromHTML(t *testing.T) {
 					IsMain:  true,
 				},
 			},
-			expectError: false,
 		},
 		{
 			name: "Image without alt text",
@@ -46,7 +44,6 @@ func TestExtractImagesFromHTML(t *testing.T) {
 					IsMain:  true,
 				},
 			},
-			expectError: false,
 		},
 		{
 			name: "Multiple images",
@@ -74,21 +71,18 @@ func TestExtractImagesFromHTML(t *testing.T) {
 					IsMain:  false,
 				},
 			},
-			expectError: false,
 		},
 		{
 			name:           "No images",
 			htmlContent:    `<div><p>Just some text without images</p></div>`,
 			expectedCount:  0,
 			expectedImages: []db.ArticleImage{},
-			expectError:    false,
 		},
 		{
 			name:           "Empty HTML",
 			htmlContent:    "",
 			expectedCount:  0,
 			expectedImages: []db.ArticleImage{},
-			expectError:    false,
 		},
 		{
 			name:          "Image with only src attribute",
@@ -101,7 +95,6 @@ func TestExtractImagesFromHTML(t *testing.T) {
 					IsMain:  true,
 				},
 			},
-			expectError: false,
 		},
 		{
 			name: "Image without src attribute is ignored",
@@ -117,7 +110,6 @@ func TestExtractImagesFromHTML(t *testing.T) {
 					IsMain:  true,
 				},
 			},
-			expectError: false,
 		},
 		{
 			name: "Nested images",
@@ -144,7 +136,6 @@ func TestExtractImagesFromHTML(t *testing.T) {
 					IsMain:  false,
 				},
 			},
-			expectError: false,
 		},
 		{
 			name:          "Image with additional attributes",
@@ -157,20 +148,12 @@ func TestExtractImagesFromHTML(t *testing.T) {
 					IsMain:  true,
 				},
 			},
-			expectError: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			images, err := extractImagesFromHTML(tt.htmlContent)
-
-			if tt.expectError && err == nil {
-				t.Errorf("Expected an error but got none")
-			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Did not expect an error but got: %v", err)
-			}
+			images := RankImages(htmlImageCandidates(tt.htmlContent, ImageOriginContent))
 
 			if len(images) != tt.expectedCount {
 				t.Errorf("Expected %d images, got %d", tt.expectedCount, len(images))
@@ -197,12 +180,9 @@ func TestExtractImagesFromHTML(t *testing.T) {
 	}
 }
 
-func TestExtractImagesFromRealWorldHTML(t *testing.T) {
+func TestRankImages_RealWorldHTML(t *testing.T) {
 	// Use the embedded sample.html file
-	images, err := extractImagesFromHTML(sampleHTML)
-	if err != nil {
-		t.Fatalf("extractImagesFromHTML returned an error: %v", err)
-	}
+	images := RankImages(htmlImageCandidates(sampleHTML, ImageOriginContent))
 
 	// Expected images from the sample.html file (5 images with srcset attributes)
 	expectedImages := []struct {
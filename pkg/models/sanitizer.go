@@ -0,0 +1,239 @@
+package models
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// sanitizedTags lists the elements kept by SanitizeHTML with the attributes
+	// each may carry. Elements not listed are unwrapped so their text survives.
+	sanitizedTags = map[atom.Atom][]string{
+		atom.P: nil, atom.Br: nil, atom.Hr: nil,
+		atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+		atom.Strong: nil, atom.Em: nil, atom.B: nil, atom.I: nil, atom.U: nil,
+		atom.S: nil, atom.Sub: nil, atom.Sup: nil, atom.Small: nil,
+		atom.Ul: nil, atom.Ol: nil, atom.Li: nil, atom.Dl: nil, atom.Dt: nil, atom.Dd: nil,
+		atom.Blockquote: {"cite"}, atom.Q: {"cite"}, atom.Pre: nil, atom.Code: nil,
+		atom.Figure: nil, atom.Figcaption: nil, atom.Caption: nil,
+		atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tfoot: nil, atom.Tr: nil,
+		atom.Th: {"colspan", "rowspan"}, atom.Td: {"colspan", "rowspan"},
+		atom.A:   {"href", "title"},
+		atom.Img: {"src", "alt", "title", "width", "height"},
+	}
+
+	// droppedTags are removed together with everything inside them
+	droppedTags = map[atom.Atom]bool{
+		atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Frame: true,
+		atom.Frameset: true, atom.Object: true, atom.Embed: true, atom.Applet: true,
+		atom.Form: true, atom.Input: true, atom.Button: true, atom.Select: true,
+		atom.Textarea: true, atom.Noscript: true, atom.Template: true, atom.Svg: true,
+		atom.Math: true, atom.Link: true, atom.Meta: true, atom.Base: true,
+		atom.Head: true, atom.Title: true, atom.Audio: true, atom.Video: true,
+		atom.Canvas: true,
+	}
+
+	// URL attributes are only kept for these schemes
+	safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+	// trackingPixelHints identify images that exist only to record a page view
+	trackingPixelHints = []string{
+		"feeds.feedburner.com/~r/", "feedburner.com/~ff/", "stats.wordpress.com",
+		"pixel.wp.com", "/pixel.gif", "/pixel.png", "/track.gif", "/tracking",
+		"doubleclick.net", "google-analytics.com", "facebook.com/tr",
+	}
+
+	// blockTags end a paragraph when converting HTML to text
+	blockTags = map[atom.Atom]bool{
+		atom.P: true, atom.Div: true, atom.Br: true, atom.Hr: true, atom.Li: true,
+		atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true,
+		atom.H6: true, atom.Blockquote: true, atom.Pre: true, atom.Tr: true,
+		atom.Table: true, atom.Ul: true, atom.Ol: true, atom.Figure: true,
+		atom.Figcaption: true, atom.Section: true, atom.Article: true,
+	}
+)
+
+// SanitizeHTML returns feed HTML reduced to a whitelist of formatting tags.
+// Scripts, styles, frames, forms and their contents are removed, event handlers
+// and inline styles are dropped, URLs must be http(s) or mailto, tracking
+// pixels are removed and links are marked rel="nofollow noopener noreferrer".
+func SanitizeHTML(input string) string {
+	return sanitize(input, true)
+}
+
+// SanitizeSummaryHTML sanitizes like SanitizeHTML and also removes images,
+// which are stored separately as article images
+func SanitizeSummaryHTML(input string) string {
+	return sanitize(input, false)
+}
+
+// HTMLToText converts HTML to plain text, separating block elements with
+// blank lines and collapsing all other whitespace
+func HTMLToText(input string) string {
+	nodes, err := parseFragment(input)
+	if err != nil {
+		return strings.TrimSpace(input)
+	}
+
+	var paragraphs []string
+	var current strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(collapseSpace(current.String())); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+		current.Reset()
+	}
+
+	var visit func(*html.Node)
+	visit = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			current.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if droppedTags[n.DataAtom] {
+				return
+			}
+		default:
+			return
+		}
+
+		block := blockTags[n.DataAtom]
+		if block {
+			flush()
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+		if block {
+			flush()
+		} else if n.DataAtom == atom.Td || n.DataAtom == atom.Th {
+			current.WriteString(" ")
+		}
+	}
+	for _, n := range nodes {
+		visit(n)
+	}
+	flush()
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func sanitize(input string, allowImages bool) string {
+	nodes, err := parseFragment(input)
+	if err != nil {
+		return html.EscapeString(input)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		renderSanitized(&b, n, allowImages)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func renderSanitized(b *strings.Builder, n *html.Node, allowImages bool) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if droppedTags[n.DataAtom] {
+		return
+	}
+	if n.DataAtom == atom.Img && (!allowImages || isTrackingPixel(n)) {
+		return
+	}
+
+	allowedAttrs, allowed := sanitizedTags[n.DataAtom]
+	if !allowed {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			renderSanitized(b, c, allowImages)
+		}
+		return
+	}
+
+	var attrs strings.Builder
+	for _, key := range allowedAttrs {
+		value, ok := sanitizedAttr(n, key)
+		if !ok {
+			continue
+		}
+		attrs.WriteString(" " + key + `="` + html.EscapeString(value) + `"`)
+	}
+
+	if n.DataAtom == atom.Img {
+		if !strings.Contains(attrs.String(), " src=") {
+			return
+		}
+		b.WriteString("<img" + attrs.String() + ">")
+		return
+	}
+	if n.DataAtom == atom.A && strings.Contains(attrs.String(), " href=") {
+		attrs.WriteString(` rel="nofollow noopener noreferrer"`)
+	}
+
+	b.WriteString("<" + n.Data + attrs.String() + ">")
+	if n.DataAtom == atom.Br || n.DataAtom == atom.Hr {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderSanitized(b, c, allowImages)
+	}
+	b.WriteString("</" + n.Data + ">")
+}
+
+// sanitizedAttr returns an attribute's value if it is present and safe
+func sanitizedAttr(n *html.Node, key string) (string, bool) {
+	value := strings.TrimSpace(attr(n, key))
+	if value == "" {
+		return "", false
+	}
+
+	switch key {
+	case "href", "src", "cite":
+		parsed, err := url.Parse(value)
+		if err != nil || (parsed.Scheme != "" && !safeSchemes[strings.ToLower(parsed.Scheme)]) {
+			return "", false
+		}
+		if key == "src" && parsed.Scheme == "mailto" {
+			return "", false
+		}
+	case "width", "height", "colspan", "rowspan":
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", false
+		}
+	}
+	return value, true
+}
+
+// isTrackingPixel reports whether an image is a 1x1 beacon or served by a known tracker
+func isTrackingPixel(n *html.Node) bool {
+	width, _ := strconv.Atoi(attr(n, "width"))
+	height, _ := strconv.Atoi(attr(n, "height"))
+	if (attr(n, "width") != "" && width <= 1) || (attr(n, "height") != "" && height <= 1) {
+		return true
+	}
+
+	src := strings.ToLower(attr(n, "src"))
+	for _, hint := range trackingPixelHints {
+		if strings.Contains(src, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFragment parses HTML as the contents of a <body> element
+func parseFragment(input string) ([]*html.Node, error) {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	return html.ParseFragment(strings.NewReader(input), context)
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Plain formatting is kept",
+			input:    `<p>Parliament <strong>passed</strong> the <em>budget</em>.</p>`,
+			expected: `<p>Parliament <strong>passed</strong> the <em>budget</em>.</p>`,
+		},
+		{
+			name:     "Scripts are removed with their contents",
+			input:    `<p>Hello</p><script>alert("xss")</script>`,
+			expected: `<p>Hello</p>`,
+		},
+		{
+			name:     "Iframes, styles and forms are removed",
+			input:    `<style>p{color:red}</style><iframe src="https://evil.example"></iframe><form><input name="q"></form><p>Text</p>`,
+			expected: `<p>Text</p>`,
+		},
+		{
+			name:     "Event handlers and inline styles are dropped",
+			input:    `<p style="font-weight: 400" onclick="steal()" class="lead">Text</p>`,
+			expected: `<p>Text</p>`,
+		},
+		{
+			name:     "Unknown tags are unwrapped",
+			input:    `<div class="x"><span style="color:red">Kept text</span></div>`,
+			expected: `Kept text`,
+		},
+		{
+			name:     "Links are marked nofollow",
+			input:    `<a href="https://news24.com/story" target="_blank">Story</a>`,
+			expected: `<a href="https://news24.com/story" rel="nofollow noopener noreferrer">Story</a>`,
+		},
+		{
+			name:     "javascript URLs are removed",
+			input:    `<a href="javascript:alert(1)">Click</a>`,
+			expected: `<a>Click</a>`,
+		},
+		{
+			name:     "Obfuscated javascript URLs are removed",
+			input:    `<a href="jav&#x09;ascript:alert(1)">Click</a>`,
+			expected: `<a>Click</a>`,
+		},
+		{
+			name:     "Images keep safe attributes",
+			input:    `<img src="https://cdn.example.com/a.jpg" alt="A photo" onerror="steal()" width="640">`,
+			expected: `<img src="https://cdn.example.com/a.jpg" alt="A photo" width="640">`,
+		},
+		{
+			name:     "Data URI images are removed",
+			input:    `<img src="data:image/svg+xml;base64,PHN2Zz4=">`,
+			expected: ``,
+		},
+		{
+			name:     "1x1 tracking pixels are removed",
+			input:    `<p>Text</p><img src="https://example.com/beacon.gif" width="1" height="1">`,
+			expected: `<p>Text</p>`,
+		},
+		{
+			name:     "Feedburner pixels are removed",
+			input:    `<img src="http://feeds.feedburner.com/~r/example/~4/abc" height="1">`,
+			expected: ``,
+		},
+		{
+			name:     "Text is escaped",
+			input:    `5 &lt; 6 &amp; "quoted"`,
+			expected: `5 &lt; 6 &amp; &#34;quoted&#34;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeHTML(tt.input)
+			if got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSanitizeSummaryHTML_RemovesImages(t *testing.T) {
+	got := SanitizeSummaryHTML(`<p><img src="https://cdn.example.com/a.jpg">Summary text</p>`)
+	if got != `<p>Summary text</p>` {
+		t.Errorf("Expected images to be removed, got '%s'", got)
+	}
+}
+
+func TestSanitizeHTML_SampleFixture(t *testing.T) {
+	got := SanitizeHTML(sampleHTML)
+
+	for _, unexpected := range []string{"style=", "<span", "<script"} {
+		if strings.Contains(got, unexpected) {
+			t.Errorf("Expected '%s' to be removed", unexpected)
+		}
+	}
+	if !strings.Contains(got, "<blockquote>") {
+		t.Errorf("Expected blockquotes to be kept")
+	}
+	if !strings.Contains(got, "Chompey Fong") {
+		t.Errorf("Expected text to be kept")
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Paragraphs become blank-line separated",
+			input:    "<p>First   paragraph.</p>\n<p>Second <b>paragraph</b>.</p>",
+			expected: "First paragraph.\n\nSecond paragraph.",
+		},
+		{
+			name:     "Entities are decoded",
+			input:    `Tom &amp; Jerry &#8211; &quot;classic&quot;`,
+			expected: `Tom & Jerry – "classic"`,
+		},
+		{
+			name:     "Scripts are ignored",
+			input:    `<p>Text</p><script>var x = 1;</script>`,
+			expected: `Text`,
+		},
+		{
+			name:     "Line breaks split paragraphs",
+			input:    `Line one<br>Line two`,
+			expected: "Line one\n\nLine two",
+		},
+		{
+			name:     "Plain text is unchanged",
+			input:    `Just a summary.`,
+			expected: `Just a summary.`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HTMLToText(tt.input)
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Markers ts_headline wraps matched words in. They are replaced with <mark>
+// after the snippet is escaped, since article text may contain markup characters.
+const (
+	HighlightStart = "⟦"
+	HighlightStop  = "⟧"
+)
+
+// HeadlineOptions configures the ts_headline snippets returned with search results
+const HeadlineOptions = "StartSel=" + HighlightStart + ", StopSel=" + HighlightStop +
+	", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// searchConfigs maps language codes to the Postgres text search configuration
+// that stems them. Languages without one, including the South African
+// languages other than English, use "simple", which only lowercases words.
+var searchConfigs = []struct {
+	language string
+	config   string
+}{
+	{"en", "english"},
+	{"fr", "french"},
+	{"pt", "portuguese"},
+	{"de", "german"},
+	{"nl", "dutch"},
+	{"es", "spanish"},
+}
+
+// SearchConfig returns the text search configuration for a language code
+func SearchConfig(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	for _, c := range searchConfigs {
+		if strings.HasPrefix(language, c.language) {
+			return c.config
+		}
+	}
+	return "simple"
+}
+
+// ArticleLanguageSQL is the language an article is indexed and filtered by:
+// the detected language when it is confident, otherwise the feed's
+func ArticleLanguageSQL(table string) string {
+	prefix := ""
+	if table != "" {
+		prefix = table + "."
+	}
+	return fmt.Sprintf("CASE WHEN %[1]slanguage_confidence >= %[2]g THEN %[1]sdetected_language ELSE %[1]slanguage END",
+		prefix, LanguageConfidenceThreshold)
+}
+
+// SearchConfigSQL is a SQL expression choosing the text search configuration
+// for an article in table; it is immutable so it can define a generated column
+func SearchConfigSQL(table string) string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, c := range searchConfigs {
+		fmt.Fprintf(&b, " WHEN LOWER(%s) LIKE '%s%%' THEN '%s'::regconfig", ArticleLanguageSQL(table), c.language, c.config)
+	}
+	b.WriteString(" ELSE 'simple'::regconfig END")
+	return b.String()
+}
+
+// SearchVectorSQL defines the articles.search_vector column. Titles weigh
+// most, then summaries, then the full text.
+func SearchVectorSQL() string {
+	config := SearchConfigSQL("")
+	return fmt.Sprintf("setweight(to_tsvector(%[1]s, COALESCE(title, '')), 'A') || "+
+		"setweight(to_tsvector(%[1]s, COALESCE(summary_text, '')), 'B') || "+
+		"setweight(to_tsvector(%[1]s, COALESCE(content_text, '')), 'C')", config)
+}
+
+// SearchQuerySQL parses a reader's search with websearch_to_tsquery, so quoted
+// phrases, "or" and -exclusions work. When the language is known only its
+// configuration is used; otherwise the search is parsed with every
+// configuration and the results combined, so it matches articles in any
+// language. It returns the SQL and the arguments for its placeholders.
+func SearchQuerySQL(language, search string) (string, []any) {
+	configs := []string{SearchConfig(language)}
+	if language == "" {
+		configs = configs[:0]
+		for _, c := range searchConfigs {
+			configs = append(configs, c.config)
+		}
+		configs = append(configs, "simple")
+	}
+
+	parts := make([]string, len(configs))
+	args := make([]any, len(configs))
+	for i, config := range configs {
+		parts[i] = fmt.Sprintf("websearch_to_tsquery('%s', ?)", config)
+		args[i] = search
+	}
+	return "(" + strings.Join(parts, " || ") + ")", args
+}
+
+// HighlightHTML escapes a ts_headline snippet and marks its matched words with <mark>
+func HighlightHTML(headline string) string {
+	escaped := html.EscapeString(headline)
+	escaped = strings.ReplaceAll(escaped, HighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, HighlightStop, "</mark>")
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchConfig(t *testing.T) {
+	tests := []struct {
+		language string
+		expected string
+	}{
+		{language: "en", expected: "english"},
+		{language: "en-ZA", expected: "english"},
+		{language: " EN-us ", expected: "english"},
+		{language: "fr", expected: "french"},
+		{language: "af", expected: "simple"},
+		{language: "zu", expected: "simple"},
+		{language: "", expected: "simple"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			if result := SearchConfig(tt.language); result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSearchQuerySQL(t *testing.T) {
+	sql, args := SearchQuerySQL("en", "load shedding")
+	if sql != "(websearch_to_tsquery('english', ?))" {
+		t.Errorf("Expected a single english query, got %s", sql)
+	}
+	if len(args) != 1 || args[0] != "load shedding" {
+		t.Errorf("Expected the search as the only argument, got %v", args)
+	}
+
+	sql, args = SearchQuerySQL("", "load shedding")
+	if strings.Count(sql, "?") != len(args) {
+		t.Errorf("Expected %d placeholders, got %s", len(args), sql)
+	}
+	if !strings.Contains(sql, "'english'") || !strings.Contains(sql, "'simple'") {
+		t.Errorf("Expected every configuration to be searched, got %s", sql)
+	}
+}
+
+func TestSearchConfigSQL(t *testing.T) {
+	sql := SearchConfigSQL("articles")
+	if !strings.Contains(sql, "articles.detected_language") {
+		t.Errorf("Expected the column to be qualified, got %s", sql)
+	}
+	if !strings.Contains(sql, "LIKE 'en%' THEN 'english'::regconfig") {
+		t.Errorf("Expected english articles to use the english configuration, got %s", sql)
+	}
+	if strings.Contains(SearchVectorSQL(), "articles.") {
+		t.Errorf("Expected the generated column to use unqualified columns")
+	}
+}
+
+func TestHighlightHTML(t *testing.T) {
+	headline := "Eskom warns of " + HighlightStart + "load" + HighlightStop + " <stage 6> " +
+		HighlightStart + "shedding" + HighlightStop
+	expected := "Eskom warns of <mark>load</mark> &lt;stage 6&gt; <mark>shedding</mark>"
+	if result := HighlightHTML(headline); result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// NearDuplicateDistance is the largest Hamming distance between two SimHash
+// fingerprints for the texts to be treated as the same story
+const NearDuplicateDistance = 12
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// simHashStopwords are ignored when fingerprinting since they carry no signal
+var simHashStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "has": true, "have": true, "he": true, "in": true,
+	"is": true, "it": true, "its": true, "of": true, "on": true, "or": true, "she": true,
+	"that": true, "the": true, "their": true, "this": true, "to": true, "was": true,
+	"were": true, "will": true, "with": true, "said": true, "says": true,
+}
+
+// SimHash computes a 64-bit locality-sensitive fingerprint of text. Texts that
+// share most of their words produce fingerprints with a small Hamming distance.
+// HTML tags are ignored. Empty input yields 0.
+func SimHash(text string) uint64 {
+	tokens := simHashTokens(text)
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	// Each distinct word is a feature weighted by how often it occurs
+	features := make(map[string]int, len(tokens))
+	for _, token := range tokens {
+		features[token]++
+	}
+
+	var weights [64]int
+	for feature, weight := range features {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit] += weight
+			} else {
+				weights[bit] -= weight
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
+
+// HammingDistance returns the number of differing bits between two fingerprints
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// IsNearDuplicate reports whether two non-empty fingerprints are within NearDuplicateDistance
+func IsNearDuplicate(a, b uint64) bool {
+	return a != 0 && b != 0 && HammingDistance(a, b) <= NearDuplicateDistance
+}
+
+func simHashTokens(text string) []string {
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	tokens := words[:0]
+	for _, word := range words {
+		if !simHashStopwords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,52 @@
+package models
+
+import "testing"
+
+func TestSimHash(t *testing.T) {
+	const (
+		wire      = "Eskom announces stage 4 load shedding from Monday evening. Eskom says stage 4 load shedding will be implemented from 16:00 on Monday due to breakdowns at several power stations."
+		rewritten = "Eskom announces Stage 4 loadshedding from Monday. The power utility said stage 4 load shedding would be implemented from 16:00 on Monday owing to breakdowns at several power stations."
+		retitled  = "Eskom to implement stage 4 load shedding from Monday evening - Eskom says stage 4 load shedding will be implemented from 4pm on Monday due to breakdowns at several power stations."
+		unrelated = "Springboks name squad for Rugby Championship. Rassie Erasmus has named a 36-man squad for the upcoming Rugby Championship, including three uncapped players."
+	)
+
+	tests := []struct {
+		name          string
+		a             string
+		b             string
+		nearDuplicate bool
+	}{
+		{"Identical text", wire, wire, true},
+		{"Markup and case are ignored", "<p>" + wire + "</p>", "<div>" + wire + "</div>", true},
+		{"Syndicated rewrite", wire, rewritten, true},
+		{"Different headline, same body", wire, retitled, true},
+		{"Unrelated story", wire, unrelated, false},
+		{"Empty text never matches", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := SimHash(tt.a), SimHash(tt.b)
+			if got := IsNearDuplicate(a, b); got != tt.nearDuplicate {
+				t.Errorf("Expected near duplicate %v, got %v (distance %d)", tt.nearDuplicate, got, HammingDistance(a, b))
+			}
+		})
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	tests := []struct {
+		a, b     uint64
+		expected int
+	}{
+		{0, 0, 0},
+		{0b1011, 0b0001, 2},
+		{0, ^uint64(0), 64},
+	}
+
+	for _, tt := range tests {
+		if got := HammingDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Expected %d, got %d", tt.expected, got)
+		}
+	}
+}
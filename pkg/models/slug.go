@@ -0,0 +1,25 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify turns a name into a lowercase, hyphen-separated URL segment, so
+// "KwaZulu-Natal" becomes "kwazulu-natal" and "North West" "north-west"
+func Slugify(name string) string {
+	var b strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(name) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = true
+			continue
+		}
+		if separate && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		separate = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package models
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Gauteng", expected: "gauteng"},
+		{name: "KwaZulu-Natal", expected: "kwazulu-natal"},
+		{name: "North West", expected: "north-west"},
+		{name: "  Eastern   Cape  ", expected: "eastern-cape"},
+		{name: "Free State (FS)", expected: "free-state-fs"},
+		{name: "---", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Slugify(tt.name); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import "strings"
+
+// maxDiffCells bounds the size of the LCS table built by DiffWords. Larger
+// inputs are reported as a single replacement of the differing middle.
+const maxDiffCells = 1_000_000
+
+// DiffWords returns a word-level diff of two texts in the style of
+// `git diff --word-diff`: removed words are wrapped in [-...-] and added words
+// in {+...+}. Unchanged words are kept as is. Identical texts yield "".
+func DiffWords(before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	a, b := strings.Fields(before), strings.Fields(after)
+
+	// Trim the common prefix and suffix so long texts with a small edit stay cheap
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var parts []string
+	parts = append(parts, a[:prefix]...)
+	parts = append(parts, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	parts = append(parts, a[len(a)-suffix:]...)
+
+	return strings.Join(parts, " ")
+}
+
+func diffMiddle(a, b []string) []string {
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		return appendChange(nil, a, b)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var parts, removed, added []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			parts = appendChange(parts, removed, added)
+			removed, added = nil, nil
+			parts = append(parts, a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, b[j])
+			j++
+		default:
+			removed = append(removed, a[i])
+			i++
+		}
+	}
+
+	return appendChange(parts, removed, added)
+}
+
+func appendChange(parts, removed, added []string) []string {
+	if len(removed) > 0 {
+		parts = append(parts, "[-"+strings.Join(removed, " ")+"-]")
+	}
+	if len(added) > 0 {
+		parts = append(parts, "{+"+strings.Join(added, " ")+"+}")
+	}
+	return parts
+}
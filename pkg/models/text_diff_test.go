@@ -0,0 +1,87 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   string
+		after    string
+		expected string
+	}{
+		{
+			name:     "Identical text",
+			before:   "Parliament passes budget",
+			after:    "Parliament passes budget",
+			expected: "",
+		},
+		{
+			name:     "Corrected word",
+			before:   "Ramaphosa visits Nairobi on Tuesday",
+			after:    "Ramaphosa visits Nairobi on Wednesday",
+			expected: "Ramaphosa visits Nairobi on [-Tuesday-] {+Wednesday+}",
+		},
+		{
+			name:     "Inserted words",
+			before:   "Eskom announces load shedding",
+			after:    "Eskom announces stage 4 load shedding",
+			expected: "Eskom announces {+stage 4+} load shedding",
+		},
+		{
+			name:     "Removed words",
+			before:   "Five people reportedly killed in Durban floods",
+			after:    "Five people killed in Durban floods",
+			expected: "Five people [-reportedly-] killed in Durban floods",
+		},
+		{
+			name:     "Changes in several places",
+			before:   "The minister said 12 schools closed",
+			after:    "The deputy minister said 15 schools closed",
+			expected: "The {+deputy+} minister said [-12-] {+15+} schools closed",
+		},
+		{
+			name:     "From empty",
+			before:   "",
+			after:    "New summary",
+			expected: "{+New summary+}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffWords(tt.before, tt.after)
+			if got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDiffWords_LargeInputFallsBackToReplacement(t *testing.T) {
+	before := strings.Repeat("alpha ", 1500) + "end"
+	after := strings.Repeat("beta ", 1500) + "end"
+
+	got := DiffWords(before, after)
+	if !strings.HasPrefix(got, "[-alpha") || !strings.HasSuffix(got, "beta+} end") {
+		t.Errorf("Expected a single replacement followed by the common suffix, got '%.40s...%s'", got, got[len(got)-20:])
+	}
+}
+
+func TestArticleContentHash(t *testing.T) {
+	hash := ArticleContentHash("Title", "Summary", "Body")
+	if len(hash) != 64 {
+		t.Errorf("Expected a 64 character hex digest, got '%s'", hash)
+	}
+	if hash != ArticleContentHash(" Title ", "Summary", "Body\n") {
+		t.Errorf("Expected surrounding whitespace to be ignored")
+	}
+	if hash == ArticleContentHash("Title", "Summary corrected", "Body") {
+		t.Errorf("Expected a different hash when the summary changes")
+	}
+	if ArticleContentHash("ab", "c", "") == ArticleContentHash("a", "bc", "") {
+		t.Errorf("Expected field boundaries to affect the hash")
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"image"
+	"image/color"
+)
+
+// ResizeImage scales src down to at most maxWidth pixels wide, keeping its
+// aspect ratio. Each destination pixel averages the source pixels it covers,
+// which avoids the aliasing of nearest neighbour sampling when shrinking.
+// Images already narrower than maxWidth are returned unchanged.
+func ResizeImage(src image.Image, maxWidth int) image.Image {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	if maxWidth <= 0 || srcWidth <= maxWidth || srcHeight == 0 {
+		return src
+	}
+
+	dstWidth := maxWidth
+	dstHeight := max(1, srcHeight*dstWidth/srcWidth)
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/dstHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/dstHeight)
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*srcWidth/dstWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/dstWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			// RGBA() values are premultiplied 16 bit; RGBA64 keeps them that way
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
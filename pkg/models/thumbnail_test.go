@@ -0,0 +1,53 @@
+package models
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResizeImage(t *testing.T) {
+	tests := []struct {
+		name           string
+		width, height  int
+		maxWidth       int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{name: "Landscape scaled down", width: 1200, height: 800, maxWidth: 400, expectedWidth: 400, expectedHeight: 266},
+		{name: "Portrait scaled down", width: 600, height: 900, maxWidth: 300, expectedWidth: 300, expectedHeight: 450},
+		{name: "Narrow image unchanged", width: 200, height: 100, maxWidth: 400, expectedWidth: 200, expectedHeight: 100},
+		{name: "Very wide strip keeps one row", width: 4000, height: 2, maxWidth: 400, expectedWidth: 400, expectedHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+			resized := ResizeImage(src, tt.maxWidth)
+			bounds := resized.Bounds()
+			if bounds.Dx() != tt.expectedWidth || bounds.Dy() != tt.expectedHeight {
+				t.Errorf("Expected %dx%d, got %dx%d", tt.expectedWidth, tt.expectedHeight, bounds.Dx(), bounds.Dy())
+			}
+		})
+	}
+}
+
+func TestResizeImage_AveragesPixels(t *testing.T) {
+	// Alternating black and white columns average to grey
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			c := color.RGBA{A: 255}
+			if x%2 == 1 {
+				c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+
+	resized := ResizeImage(src, 2)
+	r, g, b, a := resized.At(0, 0).RGBA()
+	if r>>8 != 127 || g>>8 != 127 || b>>8 != 127 || a>>8 != 255 {
+		t.Errorf("Expected grey, got r=%d g=%d b=%d a=%d", r>>8, g>>8, b>>8, a>>8)
+	}
+}
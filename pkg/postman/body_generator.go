@@ -51,6 +51,13 @@ func (bg *BodyGenerator) registerModels() {
 	// Category models
 	bg.modelMap["/category_POST"] = db.Category{}
 	bg.modelMap["/category_PATCH"] = db.Category{}
+	bg.modelMap["/category/mapping_POST"] = db.CategoryGroup{}
+	bg.modelMap["/category/mapping/{id}_PUT"] = db.CategoryGroup{}
+	bg.modelMap["/category/mapping/{id}/keywords_POST"] = db.CategoryKeyword{}
+
+	// Region models
+	bg.modelMap["/region_POST"] = db.Region{}
+	bg.modelMap["/region/{id}_PATCH"] = db.Region{}
 
 	// Directory models
 	bg.modelMap["/directory_POST"] = db.DirectoryCategory{}
@@ -1,6 +1,7 @@
 package contracts
 
 import (
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
 
 	"github.com/google/uuid"
@@ -19,9 +20,15 @@ type ArticleRepository interface {
 	GetAllWithRelations() ([]db.Article, error)
 	GetAllWithRelationsPaginated(limit, offset int) ([]db.Article, int64, error)
 	GetAllWithRelationsPaginatedAndSearch(limit, offset int, search string) ([]db.Article, int64, error)
+	GetAllWithRelationsByQuery(limit, offset int, query models.ArticleQuery) ([]db.Article, int64, error)
+	GetAllWithRelationsAfter(limit int, query models.ArticleQuery) ([]db.Article, *models.Cursor, error)
+	GetFacetsByQuery(query models.ArticleQuery) (*models.ArticleFacets, error)
 	CreateWithTransaction(tx *gorm.DB, article *db.Article) error
 	CreateWithAssociations(article *db.Article) error
 	CreateWithAssociationsAndTransaction(tx *gorm.DB, article *db.Article) error
 	ExistsByOriginalUrl(url string) (bool, error)
+	FindDuplicate(sourceID *uuid.UUID, guid, canonicalUrl, originalUrl string) (*db.Article, error)
 	SetCategories(article *db.Article, categories []db.Category) error
+	AddImage(image *db.ArticleImage) error
+	EachCategorized(batchSize int, fn func(articles []db.Article) error) error
 }
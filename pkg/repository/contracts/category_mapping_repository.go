@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+type CategoryMappingRepository interface {
+	GetAllGroups() ([]db.CategoryGroup, error)
+	GetGroupByID(id uuid.UUID) (*db.CategoryGroup, error)
+	CreateGroup(group *db.CategoryGroup) error
+	UpdateGroup(group *db.CategoryGroup) error
+	DeleteGroup(id uuid.UUID) error
+	AddKeyword(keyword *db.CategoryKeyword) error
+	DeleteKeyword(groupID, keywordID uuid.UUID) error
+}
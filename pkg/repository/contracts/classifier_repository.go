@@ -0,0 +1,10 @@
+package contracts
+
+import (
+	"vuka-api/pkg/models/db"
+)
+
+type ClassifierRepository interface {
+	Create(model *db.ClassifierModel) error
+	GetLatest(name string) (*db.ClassifierModel, error)
+}
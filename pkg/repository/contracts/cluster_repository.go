@@ -0,0 +1,18 @@
+package contracts
+
+import (
+	"time"
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+type ClusterRepository interface {
+	Lock() error
+	Create(cluster *db.StoryCluster) error
+	GetByID(id uuid.UUID) (*db.StoryCluster, error)
+	GetCandidates(since time.Time, excludeID uuid.UUID) ([]db.Article, error)
+	AssignArticles(clusterID uuid.UUID, articleIDs ...uuid.UUID) error
+	RefreshStats(clusterID uuid.UUID) error
+	GetMembers(clusterID uuid.UUID, excludeID uuid.UUID) ([]db.Article, error)
+}
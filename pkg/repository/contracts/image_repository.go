@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+type ImageRepository interface {
+	GetByID(id uuid.UUID) (*db.ArticleImage, error)
+	Update(id uuid.UUID, updates map[string]any) error
+}
@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+type IngestionRepository interface {
+	CreateRun(run *db.IngestionRun) error
+	UpdateRun(run *db.IngestionRun) error
+	GetRunByID(id uuid.UUID) (*db.IngestionRun, error)
+	GetRunsPaginated(limit, offset int) ([]db.IngestionRun, int64, error)
+	CreateAttempt(attempt *db.IngestionAttempt) error
+	GetRecentAttemptsBySource(sourceID uuid.UUID, limit int) ([]db.IngestionAttempt, error)
+	GetLastAttemptBySourceAndStatus(sourceID uuid.UUID, status string) (*db.IngestionAttempt, error)
+}
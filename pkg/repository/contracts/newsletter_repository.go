@@ -1,10 +1,14 @@
 package contracts
 
-import "vuka-api/pkg/models/db"
+import (
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+)
 
 type NewsletterRepository interface {
 	CreateSubscriber(subscriber *db.NewsletterSubscriber) error
 	GetAllSubscribers() ([]db.NewsletterSubscriber, error)
+	GetSubscribersAfter(after *models.Cursor, limit int) ([]db.NewsletterSubscriber, *models.Cursor, error)
 	GetSubscriberByID(id string) (*db.NewsletterSubscriber, error)
 	UpdateSubscriber(subscriber *db.NewsletterSubscriber) error
 	DeleteSubscriber(id string) error
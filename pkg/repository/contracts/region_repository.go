@@ -0,0 +1,12 @@
+package contracts
+
+import "vuka-api/pkg/models/db"
+
+type RegionRepository interface {
+	Create(region *db.Region) error
+	GetByID(id string) (*db.Region, error)
+	GetBySlug(slug string) (*db.Region, error)
+	GetAll() ([]db.Region, error)
+	Update(region *db.Region) error
+	Delete(id string) error
+}
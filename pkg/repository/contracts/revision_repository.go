@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"vuka-api/pkg/models/db"
+
+	"github.com/google/uuid"
+)
+
+type RevisionRepository interface {
+	Create(revision *db.ArticleRevision) error
+	GetByArticleID(articleID uuid.UUID) ([]db.ArticleRevision, error)
+}
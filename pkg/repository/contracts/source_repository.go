@@ -7,5 +7,6 @@ type SourceRepository interface {
 	GetByID(id string) (*db.Source, error)
 	GetAll() ([]db.Source, error)
 	Update(source *db.Source) error
+	UpdateFields(id string, updates map[string]any) error
 	Delete(id string) error
 }
@@ -1,6 +1,9 @@
 package implementations
 
 import (
+	"strings"
+	"time"
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
 	"vuka-api/pkg/repository/contracts"
 
@@ -79,36 +82,198 @@ func (r *articleRepository) GetAllWithRelationsPaginated(limit, offset int) ([]d
 }
 
 func (r *articleRepository) GetAllWithRelationsPaginatedAndSearch(limit, offset int, search string) ([]db.Article, int64, error) {
+	return r.GetAllWithRelationsByQuery(limit, offset, models.ArticleQuery{Search: search})
+}
+
+func (r *articleRepository) GetAllWithRelationsByQuery(limit, offset int, articleQuery models.ArticleQuery) ([]db.Article, int64, error) {
 	var articles []db.Article
 	var total int64
 
-	query := r.db.Model(&db.Article{})
-
-	// Apply search filter if provided
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		query = query.Joins("LEFT JOIN sources ON articles.source_id = sources.id").
-			Where("LOWER(articles.title) LIKE LOWER(?) OR LOWER(sources.name) LIKE LOWER(?)", searchPattern, searchPattern)
-	}
+	query := r.filterArticles(articleQuery)
 
 	// Count total articles with search filter
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	// Rank search results by relevance and add a highlighted snippet
+	search := strings.TrimSpace(articleQuery.Search)
+	if search != "" {
+		tsQuery, tsArgs := models.SearchQuerySQL(articleQuery.Language, search)
+		args := append(append([]any{}, tsArgs...), tsArgs...)
+		args = append(args, models.HeadlineOptions)
+		query = query.Select(articleColumns(articleQuery)+", "+
+			"ts_rank(articles.search_vector, "+tsQuery+") AS search_rank, "+
+			"ts_headline("+models.SearchConfigSQL("articles")+", "+
+			"COALESCE(NULLIF(articles.content_text, ''), NULLIF(articles.summary_text, ''), articles.title), "+
+			tsQuery+", ?) AS search_headline", args...)
+	} else {
+		// Joined tables would otherwise make gorm list every field, including
+		// the search fields that only exist in search results
+		query = query.Select(articleColumns(articleQuery))
+	}
+
+	direction := " DESC"
+	if articleQuery.Ascending {
+		direction = " ASC"
+	}
+	switch {
+	case articleQuery.Sort == models.SortRelevance && search != "":
+		query = query.Order("search_rank" + direction)
+	case articleQuery.Sort == models.SortPublishedAt:
+		query = query.Order("articles.published_at" + direction)
+	}
+
 	// Get paginated articles with relations and search filter
-	err := query.Preload("Source").
-		Preload("Region").
-		Preload("Images").
-		Preload("Categories").
-		Order("articles.created_at DESC").
+	err := preloadArticleRelations(query, articleQuery).
+		Order("articles.created_at" + direction).
 		Limit(limit).
 		Offset(offset).
 		Find(&articles).Error
 
+	for i := range articles {
+		if articles[i].SearchHeadline != "" {
+			articles[i].SearchHeadline = models.HighlightHTML(articles[i].SearchHeadline)
+		}
+	}
 	return articles, total, err
 }
 
+// GetAllWithRelationsAfter returns a keyset-paginated page of articles matching
+// the query and the cursor of the next page, which is nil on the last page
+func (r *articleRepository) GetAllWithRelationsAfter(limit int, articleQuery models.ArticleQuery) ([]db.Article, *models.Cursor, error) {
+	var articles []db.Article
+
+	column := "published_at"
+	if articleQuery.Sort == models.SortCreatedAt {
+		column = "created_at"
+	}
+
+	query := r.filterArticles(articleQuery).Select(articleColumns(articleQuery))
+	query = keysetPage(query, "articles", column, articleQuery.After, limit, articleQuery.Ascending)
+	err := preloadArticleRelations(query, articleQuery).Find(&articles).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, next := models.KeysetPage(articles, limit, string(articleQuery.Sort), func(article db.Article) (time.Time, uuid.UUID) {
+		if articleQuery.Sort == models.SortCreatedAt {
+			return article.CreatedAt, article.ID
+		}
+		return article.PublishedAt, article.ID
+	})
+	return page, next, nil
+}
+
+// GetFacetsByQuery counts the articles matching the query per category and per source
+func (r *articleRepository) GetFacetsByQuery(articleQuery models.ArticleQuery) (*models.ArticleFacets, error) {
+	facets := &models.ArticleFacets{Categories: []models.FacetCount{}, Sources: []models.FacetCount{}}
+
+	categoryQuery := articleQuery
+	categoryQuery.CategoryIDs = nil
+	err := r.filterArticles(categoryQuery).
+		Select("categories.id, categories.name, COUNT(DISTINCT articles.id) AS count").
+		Joins("JOIN article_categories ON article_categories.article_id = articles.id").
+		Joins("JOIN categories ON categories.id = article_categories.category_id AND categories.deleted_at IS NULL").
+		Group("categories.id, categories.name").
+		Order("count DESC, categories.name").
+		Scan(&facets.Categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sourceQuery := articleQuery
+	sourceQuery.SourceIDs = nil
+	err = r.filterArticles(sourceQuery).
+		Select("sources.id, sources.name, COUNT(articles.id) AS count").
+		Where("sources.id IS NOT NULL").
+		Group("sources.id, sources.name").
+		Order("count DESC, sources.name").
+		Scan(&facets.Sources).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// articleColumns lists the article columns a listing selects
+func articleColumns(articleQuery models.ArticleQuery) string {
+	if articleQuery.FieldSet == nil {
+		return "articles.*"
+	}
+	columns := articleQuery.FieldSet.Columns()
+	for i, column := range columns {
+		columns[i] = "articles." + column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// preloadArticleRelations loads the relations a listing includes
+func preloadArticleRelations(query *gorm.DB, articleQuery models.ArticleQuery) *gorm.DB {
+	preloads := []string{"Source", "Region", "Images", "Categories", "Cluster"}
+	if articleQuery.FieldSet != nil {
+		preloads = articleQuery.FieldSet.Preloads()
+	}
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+	return query
+}
+
+// filterArticles builds a query over articles, joined to their sources,
+// restricted by every filter in the article query
+func (r *articleRepository) filterArticles(articleQuery models.ArticleQuery) *gorm.DB {
+	query := r.db.Model(&db.Article{}).
+		Joins("LEFT JOIN sources ON articles.source_id = sources.id AND sources.deleted_at IS NULL")
+
+	// Match the full text, or the name of the article's source
+	if search := strings.TrimSpace(articleQuery.Search); search != "" {
+		tsQuery, tsArgs := models.SearchQuerySQL(articleQuery.Language, search)
+		args := append(tsArgs, "%"+search+"%")
+		query = query.Where("articles.search_vector @@ "+tsQuery+" OR LOWER(sources.name) LIKE LOWER(?)", args...)
+	}
+
+	// Trust the detected language when it is confident, otherwise the feed's
+	if articleQuery.Language != "" {
+		query = query.Where("LOWER("+models.ArticleLanguageSQL("articles")+") LIKE ?",
+			strings.ToLower(articleQuery.Language)+"%")
+	}
+
+	if articleQuery.RegionID != "" {
+		query = query.Where("articles.region_id = ?", articleQuery.RegionID)
+	}
+	if articleQuery.RegionSlug != "" {
+		query = query.Where("articles.region_id IN (SELECT id FROM regions WHERE slug = ? AND deleted_at IS NULL)", articleQuery.RegionSlug)
+	}
+
+	if len(articleQuery.CategoryIDs) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM article_categories WHERE article_categories.article_id = articles.id AND article_categories.category_id IN ?)",
+			articleQuery.CategoryIDs)
+	}
+	if len(articleQuery.SourceIDs) > 0 {
+		query = query.Where("articles.source_id IN ?", articleQuery.SourceIDs)
+	}
+
+	if articleQuery.Featured != nil {
+		query = query.Where("articles.is_featured = ?", *articleQuery.Featured)
+	}
+	if articleQuery.PublishedFrom != nil {
+		query = query.Where("articles.published_at >= ?", *articleQuery.PublishedFrom)
+	}
+	if articleQuery.PublishedTo != nil {
+		query = query.Where("articles.published_at < ?", *articleQuery.PublishedTo)
+	}
+
+	// Hide every clustered article except the one representing its story
+	if articleQuery.CollapseClusters {
+		query = query.Joins("LEFT JOIN story_clusters ON articles.cluster_id = story_clusters.id").
+			Where("story_clusters.id IS NULL OR story_clusters.representative_id = articles.id")
+	}
+
+	return query
+}
+
 func (r *articleRepository) Update(id uuid.UUID, updates map[string]any) error {
 	return r.db.Model(&db.Article{}).Where("id = ?", id).Updates(updates).Error
 }
@@ -145,12 +310,44 @@ func (r *articleRepository) ExistsByOriginalUrl(url string) (bool, error) {
 	return count > 0, err
 }
 
+// FindDuplicate returns the first article with the same GUID from the same
+// source, the same canonical URL, or the same original URL
+func (r *articleRepository) FindDuplicate(sourceID *uuid.UUID, guid, canonicalUrl, originalUrl string) (*db.Article, error) {
+	// Blank identifiers are never compared, or every article without a URL
+	// would be a duplicate of every other
+	var conditions *gorm.DB
+	or := func(query string, args ...any) {
+		if conditions == nil {
+			conditions = r.db.Where(query, args...)
+		} else {
+			conditions = conditions.Or(query, args...)
+		}
+	}
+	if originalUrl != "" {
+		or("original_url = ?", originalUrl)
+	}
+	if canonicalUrl != "" {
+		or("canonical_url = ?", canonicalUrl)
+	}
+	if sourceID != nil && guid != "" {
+		or("source_id = ? AND guid = ?", *sourceID, guid)
+	}
+
+	var article db.Article
+	if conditions == nil {
+		return &article, gorm.ErrRecordNotFound
+	}
+	err := r.db.Where(conditions).First(&article).Error
+	return &article, err
+}
+
 func (r *articleRepository) GetWithRelations(id uuid.UUID) (*db.Article, error) {
 	var article db.Article
 	err := r.db.Preload("Source").
 		Preload("Region").
 		Preload("Images").
 		Preload("Categories").
+		Preload("Cluster").
 		First(&article, id).Error
 	return &article, err
 }
@@ -158,3 +355,21 @@ func (r *articleRepository) GetWithRelations(id uuid.UUID) (*db.Article, error)
 func (r *articleRepository) SetCategories(article *db.Article, categories []db.Category) error {
 	return r.db.Model(article).Association("Categories").Replace(categories)
 }
+
+func (r *articleRepository) AddImage(image *db.ArticleImage) error {
+	return r.db.Create(image).Error
+}
+
+// EachCategorized passes articles whose categories came from their feed,
+// rather than the classifier or the source's default, with categories loaded,
+// to fn in batches
+func (r *articleRepository) EachCategorized(batchSize int, fn func(articles []db.Article) error) error {
+	var articles []db.Article
+	return r.db.Preload("Categories").
+		Select("id", "title", "summary_text", "content_text").
+		Where("categories_inferred = ? AND category_defaulted = ?", false, false).
+		Where("EXISTS (SELECT 1 FROM article_categories WHERE article_categories.article_id = articles.id)").
+		FindInBatches(&articles, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(articles)
+		}).Error
+}
@@ -0,0 +1,88 @@
+package implementations
+
+import (
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type categoryMappingRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryMappingRepository(db *gorm.DB) contracts.CategoryMappingRepository {
+	return &categoryMappingRepository{db: db}
+}
+
+func (r *categoryMappingRepository) GetAllGroups() ([]db.CategoryGroup, error) {
+	var groups []db.CategoryGroup
+	err := r.db.Preload("Keywords", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("keyword")
+	}).Order("name").Find(&groups).Error
+	return groups, err
+}
+
+func (r *categoryMappingRepository) GetGroupByID(id uuid.UUID) (*db.CategoryGroup, error) {
+	var group db.CategoryGroup
+	err := r.db.Preload("Keywords", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("keyword")
+	}).First(&group, "id = ?", id).Error
+	return &group, err
+}
+
+func (r *categoryMappingRepository) CreateGroup(group *db.CategoryGroup) error {
+	return r.db.Create(group).Error
+}
+
+// UpdateGroup renames a group and replaces its keywords with group.Keywords
+func (r *categoryMappingRepository) UpdateGroup(group *db.CategoryGroup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&db.CategoryGroup{}).Where("id = ?", group.ID).Update("name", group.Name).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("group_id = ?", group.ID).Delete(&db.CategoryKeyword{}).Error; err != nil {
+			return err
+		}
+		if len(group.Keywords) == 0 {
+			return nil
+		}
+		for i := range group.Keywords {
+			group.Keywords[i].GroupID = group.ID
+		}
+		return tx.Create(&group.Keywords).Error
+	})
+}
+
+// DeleteGroup permanently removes a group and its keywords so the name can be reused
+func (r *categoryMappingRepository) DeleteGroup(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("group_id = ?", id).Delete(&db.CategoryKeyword{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Delete(&db.CategoryGroup{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *categoryMappingRepository) AddKeyword(keyword *db.CategoryKeyword) error {
+	return r.db.Create(keyword).Error
+}
+
+func (r *categoryMappingRepository) DeleteKeyword(groupID, keywordID uuid.UUID) error {
+	result := r.db.Unscoped().Where("group_id = ? AND id = ?", groupID, keywordID).Delete(&db.CategoryKeyword{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package implementations
+
+import (
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"gorm.io/gorm"
+)
+
+type classifierRepository struct {
+	db *gorm.DB
+}
+
+func NewClassifierRepository(db *gorm.DB) contracts.ClassifierRepository {
+	return &classifierRepository{db: db}
+}
+
+func (r *classifierRepository) Create(model *db.ClassifierModel) error {
+	return r.db.Create(model).Error
+}
+
+func (r *classifierRepository) GetLatest(name string) (*db.ClassifierModel, error) {
+	var model db.ClassifierModel
+	err := r.db.Where("name = ?", name).Order("trained_at DESC").First(&model).Error
+	return &model, err
+}
@@ -0,0 +1,85 @@
+package implementations
+
+import (
+	"time"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type clusterRepository struct {
+	db *gorm.DB
+}
+
+func NewClusterRepository(db *gorm.DB) contracts.ClusterRepository {
+	return &clusterRepository{db: db}
+}
+
+// clusterLockKey identifies the advisory lock that serialises cluster assignment
+const clusterLockKey = 7_310_452
+
+// Lock takes a transaction-scoped advisory lock so concurrent ingestion workers
+// never both create a cluster for the same story. It is released on commit.
+func (r *clusterRepository) Lock() error {
+	return r.db.Exec("SELECT pg_advisory_xact_lock(?)", clusterLockKey).Error
+}
+
+func (r *clusterRepository) Create(cluster *db.StoryCluster) error {
+	return r.db.Create(cluster).Error
+}
+
+func (r *clusterRepository) GetByID(id uuid.UUID) (*db.StoryCluster, error) {
+	var cluster db.StoryCluster
+	err := r.db.First(&cluster, "id = ?", id).Error
+	return &cluster, err
+}
+
+// GetCandidates returns the fingerprint and cluster of every article published
+// since the given time, which is all that is needed to compare fingerprints
+func (r *clusterRepository) GetCandidates(since time.Time, excludeID uuid.UUID) ([]db.Article, error) {
+	var articles []db.Article
+	err := r.db.Select("id", "sim_hash", "cluster_id", "published_at").
+		Where("sim_hash <> 0 AND published_at >= ? AND id <> ?", since, excludeID).
+		Find(&articles).Error
+	return articles, err
+}
+
+func (r *clusterRepository) AssignArticles(clusterID uuid.UUID, articleIDs ...uuid.UUID) error {
+	return r.db.Model(&db.Article{}).Where("id IN ?", articleIDs).Update("cluster_id", clusterID).Error
+}
+
+// RefreshStats recounts the articles in a cluster, makes the earliest
+// published one its representative and bumps its last-seen time
+func (r *clusterRepository) RefreshStats(clusterID uuid.UUID) error {
+	var count int64
+	if err := r.db.Model(&db.Article{}).Where("cluster_id = ?", clusterID).Count(&count).Error; err != nil {
+		return err
+	}
+	var representative db.Article
+	err := r.db.Select("id").
+		Where("cluster_id = ?", clusterID).
+		Order("published_at ASC, id ASC").
+		First(&representative).Error
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&db.StoryCluster{}).Where("id = ?", clusterID).Updates(map[string]any{
+		"article_count":     count,
+		"representative_id": representative.ID,
+		"last_seen_at":      time.Now(),
+	}).Error
+}
+
+func (r *clusterRepository) GetMembers(clusterID uuid.UUID, excludeID uuid.UUID) ([]db.Article, error) {
+	var articles []db.Article
+	err := r.db.Preload("Source").
+		Preload("Region").
+		Preload("Images").
+		Preload("Categories").
+		Where("cluster_id = ? AND id <> ?", clusterID, excludeID).
+		Order("published_at ASC").
+		Find(&articles).Error
+	return articles, err
+}
@@ -0,0 +1,27 @@
+package implementations
+
+import (
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type imageRepository struct {
+	db *gorm.DB
+}
+
+func NewImageRepository(db *gorm.DB) contracts.ImageRepository {
+	return &imageRepository{db: db}
+}
+
+func (r *imageRepository) GetByID(id uuid.UUID) (*db.ArticleImage, error) {
+	var image db.ArticleImage
+	err := r.db.First(&image, "id = ?", id).Error
+	return &image, err
+}
+
+func (r *imageRepository) Update(id uuid.UUID, updates map[string]any) error {
+	return r.db.Model(&db.ArticleImage{}).Where("id = ?", id).Updates(updates).Error
+}
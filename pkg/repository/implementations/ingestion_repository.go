@@ -0,0 +1,73 @@
+package implementations
+
+import (
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ingestionRepository struct {
+	db *gorm.DB
+}
+
+func NewIngestionRepository(db *gorm.DB) contracts.IngestionRepository {
+	return &ingestionRepository{db: db}
+}
+
+func (r *ingestionRepository) CreateRun(run *db.IngestionRun) error {
+	return r.db.Omit("Attempts").Create(run).Error
+}
+
+func (r *ingestionRepository) UpdateRun(run *db.IngestionRun) error {
+	return r.db.Omit("Attempts").Save(run).Error
+}
+
+func (r *ingestionRepository) GetRunByID(id uuid.UUID) (*db.IngestionRun, error) {
+	var run db.IngestionRun
+	err := r.db.Preload("Attempts", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("started_at ASC")
+	}).First(&run, "id = ?", id).Error
+	return &run, err
+}
+
+func (r *ingestionRepository) GetRunsPaginated(limit, offset int) ([]db.IngestionRun, int64, error) {
+	var runs []db.IngestionRun
+	var total int64
+
+	if err := r.db.Model(&db.IngestionRun{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Order("started_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&runs).Error
+
+	return runs, total, err
+}
+
+func (r *ingestionRepository) CreateAttempt(attempt *db.IngestionAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+func (r *ingestionRepository) GetRecentAttemptsBySource(sourceID uuid.UUID, limit int) ([]db.IngestionAttempt, error) {
+	var attempts []db.IngestionAttempt
+	err := r.db.Where("source_id = ?", sourceID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&attempts).Error
+	return attempts, err
+}
+
+func (r *ingestionRepository) GetLastAttemptBySourceAndStatus(sourceID uuid.UUID, status string) (*db.IngestionAttempt, error) {
+	var attempt db.IngestionAttempt
+	err := r.db.Where("source_id = ? AND status = ?", sourceID, status).
+		Order("started_at DESC").
+		First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
@@ -0,0 +1,24 @@
+package implementations
+
+import (
+	"vuka-api/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// keysetPage orders a query by (column, id) and restricts it to the rows after
+// the cursor. One row more than limit is fetched so models.KeysetPage can tell
+// whether another page follows.
+func keysetPage(query *gorm.DB, table, column string, after *models.Cursor, limit int, ascending bool) *gorm.DB {
+	direction, comparison := " DESC", " < "
+	if ascending {
+		direction, comparison = " ASC", " > "
+	}
+
+	sortColumn := table + "." + column
+	idColumn := table + ".id"
+	if after != nil {
+		query = query.Where("("+sortColumn+", "+idColumn+")"+comparison+"(?, ?)", after.Time, after.ID)
+	}
+	return query.Order(sortColumn + direction).Order(idColumn + direction).Limit(limit + 1)
+}
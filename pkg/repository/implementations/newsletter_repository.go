@@ -1,9 +1,12 @@
 package implementations
 
 import (
+	"time"
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
 	"vuka-api/pkg/repository/contracts"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -25,6 +28,22 @@ func (r *NewsletterRepository) GetAllSubscribers() ([]db.NewsletterSubscriber, e
 	return subscribers, err
 }
 
+// GetSubscribersAfter returns a page of subscribers, newest first, and the
+// cursor of the next page, which is nil on the last page
+func (r *NewsletterRepository) GetSubscribersAfter(after *models.Cursor, limit int) ([]db.NewsletterSubscriber, *models.Cursor, error) {
+	var subscribers []db.NewsletterSubscriber
+	err := keysetPage(r.Db.Model(&db.NewsletterSubscriber{}), "newsletter_subscribers", "created_at", after, limit, false).
+		Find(&subscribers).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, next := models.KeysetPage(subscribers, limit, "createdAt", func(subscriber db.NewsletterSubscriber) (time.Time, uuid.UUID) {
+		return subscriber.CreatedAt, subscriber.ID
+	})
+	return page, next, nil
+}
+
 func (r *NewsletterRepository) GetSubscriberByID(id string) (*db.NewsletterSubscriber, error) {
 	var subscriber db.NewsletterSubscriber
 	err := r.Db.Where("id = ?", id).First(&subscriber).Error
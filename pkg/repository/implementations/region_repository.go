@@ -0,0 +1,55 @@
+package implementations
+
+import (
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"gorm.io/gorm"
+)
+
+type regionRepository struct {
+	db *gorm.DB
+}
+
+func NewRegionRepository(db *gorm.DB) contracts.RegionRepository {
+	return &regionRepository{db: db}
+}
+
+func (r *regionRepository) Create(region *db.Region) error {
+	return r.db.Omit("Articles").Create(region).Error
+}
+
+func (r *regionRepository) GetByID(id string) (*db.Region, error) {
+	var region db.Region
+	err := r.db.First(&region, "id = ?", id).Error
+	return &region, err
+}
+
+func (r *regionRepository) GetBySlug(slug string) (*db.Region, error) {
+	var region db.Region
+	err := r.db.First(&region, "slug = ?", slug).Error
+	return &region, err
+}
+
+func (r *regionRepository) GetAll() ([]db.Region, error) {
+	var regions []db.Region
+	err := r.db.Order("name").Find(&regions).Error
+	return regions, err
+}
+
+func (r *regionRepository) Update(region *db.Region) error {
+	return r.db.Model(region).Select("name", "slug").Updates(region).Error
+}
+
+// Delete removes the region permanently so its slug can be reused and the
+// foreign keys on articles and sources are cleared
+func (r *regionRepository) Delete(id string) error {
+	result := r.db.Unscoped().Delete(&db.Region{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
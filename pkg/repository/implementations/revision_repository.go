@@ -0,0 +1,27 @@
+package implementations
+
+import (
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type revisionRepository struct {
+	db *gorm.DB
+}
+
+func NewRevisionRepository(db *gorm.DB) contracts.RevisionRepository {
+	return &revisionRepository{db: db}
+}
+
+func (r *revisionRepository) Create(revision *db.ArticleRevision) error {
+	return r.db.Create(revision).Error
+}
+
+func (r *revisionRepository) GetByArticleID(articleID uuid.UUID) ([]db.ArticleRevision, error) {
+	var revisions []db.ArticleRevision
+	err := r.db.Where("article_id = ?", articleID).Order("detected_at DESC").Find(&revisions).Error
+	return revisions, err
+}
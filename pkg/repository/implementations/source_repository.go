@@ -7,6 +7,20 @@ import (
 	"gorm.io/gorm"
 )
 
+// sourceEditorFields are the columns editors manage. Fetch and failure state
+// only changes through UpdateFields, so an edit never re-enables a source.
+var sourceEditorFields = []string{
+	"Name", "WebsiteUrl", "RssFeedUrl", "ExtractFullText",
+	"DefaultLanguage", "DefaultRegionID", "DefaultCategory",
+}
+
+// sourceStateFields are the fetch and failure columns maintained by ingestion
+var sourceStateFields = []string{
+	"ETag", "LastModified", "LastFetchedAt", "LastStatusCode",
+	"ConsecutiveFailures", "LastError", "NextFetchAt",
+	"Disabled", "DisabledAt", "DisabledReason",
+}
+
 type sourceRepository struct {
 	db *gorm.DB
 }
@@ -16,23 +30,35 @@ func NewSourceRepository(db *gorm.DB) contracts.SourceRepository {
 }
 
 func (r *sourceRepository) Create(source *db.Source) error {
-	return r.db.Create(source).Error
+	return r.db.Omit(append([]string{"DefaultRegion"}, sourceStateFields...)...).Create(source).Error
 }
 
 func (r *sourceRepository) GetByID(id string) (*db.Source, error) {
 	var source db.Source
-	err := r.db.First(&source, "id = ?", id).Error
+	err := r.db.Preload("DefaultRegion").First(&source, "id = ?", id).Error
 	return &source, err
 }
 
 func (r *sourceRepository) GetAll() ([]db.Source, error) {
 	var sources []db.Source
-	err := r.db.Find(&sources).Error
+	err := r.db.Preload("DefaultRegion").Find(&sources).Error
 	return sources, err
 }
 
+// Update saves the editor-managed fields of a source, including blank ones
 func (r *sourceRepository) Update(source *db.Source) error {
-	return r.db.Save(source).Error
+	result := r.db.Model(source).Select(sourceEditorFields).Updates(source)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *sourceRepository) UpdateFields(id string, updates map[string]any) error {
+	return r.db.Model(&db.Source{}).Where("id = ?", id).Updates(updates).Error
 }
 
 func (r *sourceRepository) Delete(id string) error {
@@ -8,6 +8,7 @@ import (
 )
 
 type Repositories struct {
+	db         *gorm.DB
 	Article    contracts.ArticleRepository
 	User       contracts.UserRepository
 	Role       contracts.RoleRepository
@@ -16,10 +17,18 @@ type Repositories struct {
 	Directory  contracts.DirectoryRepository
 	Permission contracts.PermissionRepository
 	Newsletter contracts.NewsletterRepository
+	Ingestion  contracts.IngestionRepository
+	Cluster    contracts.ClusterRepository
+	Revision   contracts.RevisionRepository
+	Image      contracts.ImageRepository
+	Mapping    contracts.CategoryMappingRepository
+	Classifier contracts.ClassifierRepository
+	Region     contracts.RegionRepository
 }
 
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
+		db:         db,
 		Article:    implementations.NewArticleRepository(db),
 		User:       implementations.NewUserRepository(db),
 		Role:       implementations.NewRoleRepository(db),
@@ -28,5 +37,26 @@ func NewRepositories(db *gorm.DB) *Repositories {
 		Directory:  implementations.NewDirectoryRepository(db),
 		Permission: implementations.NewPermissionRepository(db),
 		Newsletter: implementations.NewNewsletterRepository(db),
+		Ingestion:  implementations.NewIngestionRepository(db),
+		Cluster:    implementations.NewClusterRepository(db),
+		Revision:   implementations.NewRevisionRepository(db),
+		Image:      implementations.NewImageRepository(db),
+		Mapping:    implementations.NewCategoryMappingRepository(db),
+		Classifier: implementations.NewClassifierRepository(db),
+		Region:     implementations.NewRegionRepository(db),
 	}
 }
+
+// DB returns the connection the repositories are bound to, which is the
+// transaction itself inside Transaction
+func (r *Repositories) DB() *gorm.DB {
+	return r.db
+}
+
+// Transaction runs fn with repositories bound to a single database transaction.
+// The transaction is committed when fn returns nil and rolled back otherwise.
+func (r *Repositories) Transaction(fn func(tx *Repositories) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
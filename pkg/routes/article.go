@@ -16,6 +16,10 @@ var RegisterArticleRoutes = func(router *mux.Router) {
 		Methods(http.MethodGet)
 	articleRouter.HandleFunc("/{id}", articleController.GetArticle).
 		Methods(http.MethodGet)
+	articleRouter.HandleFunc("/{id}/related", articleController.GetRelatedArticles).
+		Methods(http.MethodGet)
+	articleRouter.HandleFunc("/{id}/revisions", articleController.GetArticleRevisions).
+		Methods(http.MethodGet)
 	articleRouter.HandleFunc("/rss", articleController.CreateFromRssFeed).
 		Methods(http.MethodPost)
 
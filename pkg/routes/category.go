@@ -1,7 +1,9 @@
 package routes
 
 import (
+	"net/http"
 	"vuka-api/pkg/controllers"
+	"vuka-api/pkg/middleware"
 
 	"github.com/gorilla/mux"
 )
@@ -9,4 +11,17 @@ import (
 var RegisterCategoryRoutes = func(router *mux.Router) {
 	controller := controllers.NewCategoryController()
 	router.HandleFunc("/category", controller.GetAllCategories).Methods("GET")
+
+	// Admin-only routes for the rules that group feed categories
+	mappingController := controllers.NewCategoryMappingController()
+	mappingRouter := router.PathPrefix("/category/mapping").Subrouter()
+	mappingRouter.Use(middleware.VerifyTokenAndAdmin)
+	mappingRouter.HandleFunc("", mappingController.GetGroups).Methods(http.MethodGet)
+	mappingRouter.HandleFunc("", mappingController.CreateGroup).Methods(http.MethodPost)
+	mappingRouter.HandleFunc("/reload", mappingController.Reload).Methods(http.MethodPost)
+	mappingRouter.HandleFunc("/{id}", mappingController.GetGroupByID).Methods(http.MethodGet)
+	mappingRouter.HandleFunc("/{id}", mappingController.UpdateGroup).Methods(http.MethodPut)
+	mappingRouter.HandleFunc("/{id}", mappingController.DeleteGroup).Methods(http.MethodDelete)
+	mappingRouter.HandleFunc("/{id}/keywords", mappingController.AddKeyword).Methods(http.MethodPost)
+	mappingRouter.HandleFunc("/{id}/keywords/{keywordId}", mappingController.DeleteKeyword).Methods(http.MethodDelete)
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"net/http"
+	"vuka-api/pkg/controllers"
+
+	"github.com/gorilla/mux"
+)
+
+var RegisterFeedRoutes = func(router *mux.Router) {
+	feedController := controllers.NewFeedController()
+
+	// Public routes; each feed is served as RSS 2.0 from .xml and as Atom from .atom
+	feedRouter := router.PathPrefix("/feeds").Subrouter()
+	for _, extension := range []string{".xml", ".atom"} {
+		feedRouter.HandleFunc("/latest"+extension, feedController.GetLatestFeed).Methods(http.MethodGet, http.MethodHead)
+		feedRouter.HandleFunc("/featured"+extension, feedController.GetFeaturedFeed).Methods(http.MethodGet, http.MethodHead)
+		feedRouter.HandleFunc("/category/{id}"+extension, feedController.GetCategoryFeed).Methods(http.MethodGet, http.MethodHead)
+		feedRouter.HandleFunc("/region/{slug}"+extension, feedController.GetRegionFeed).Methods(http.MethodGet, http.MethodHead)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+	"vuka-api/pkg/controllers"
+	"vuka-api/pkg/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+var RegisterIngestionRoutes = func(router *mux.Router) {
+	ingestionController := controllers.NewIngestionController()
+
+	// Admin-only routes for ingestion history
+	protectedRouter := router.PathPrefix("/ingestion").Subrouter()
+	protectedRouter.Use(middleware.VerifyTokenAndAdmin)
+
+	protectedRouter.HandleFunc("/runs", ingestionController.GetRuns).Methods(http.MethodGet)
+	protectedRouter.HandleFunc("/runs/{id}", ingestionController.GetRunByID).Methods(http.MethodGet)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"net/http"
+	"vuka-api/pkg/controllers"
+
+	"github.com/gorilla/mux"
+)
+
+var RegisterMediaRoutes = func(router *mux.Router) {
+	mediaController := controllers.NewMediaController()
+
+	// Public route serving stored article images and their thumbnails
+	router.HandleFunc("/media/{id}", mediaController.GetMedia).Methods(http.MethodGet)
+}
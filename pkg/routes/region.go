@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"net/http"
+	"vuka-api/pkg/controllers"
+	"vuka-api/pkg/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+var RegisterRegionRoutes = func(router *mux.Router) {
+	regionController := controllers.NewRegionController()
+
+	// Public routes (no authentication required)
+	regionRouter := router.PathPrefix("/region").Subrouter()
+	regionRouter.HandleFunc("", regionController.GetAllRegions).Methods(http.MethodGet)
+	regionRouter.HandleFunc("/{slug}", regionController.GetRegionBySlug).Methods(http.MethodGet)
+	regionRouter.HandleFunc("/{slug}/articles", regionController.GetRegionArticles).Methods(http.MethodGet)
+
+	// Protected routes (admin only)
+	regionRouter.HandleFunc("", middleware.VerifyTokenAndAdminFunc(regionController.CreateRegion)).
+		Methods(http.MethodPost)
+	regionRouter.HandleFunc("/{id}", middleware.VerifyTokenAndAdminFunc(regionController.UpdateRegion)).
+		Methods(http.MethodPatch)
+	regionRouter.HandleFunc("/{id}", middleware.VerifyTokenAndAdminFunc(regionController.DeleteRegion)).
+		Methods(http.MethodDelete)
+}
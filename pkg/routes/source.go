@@ -24,4 +24,6 @@ var RegisterSourceRoutes = func(router *mux.Router) {
 	protectedRouter.HandleFunc("/{id}", sourceController.UpdateSource).Methods(http.MethodPatch)
 	protectedRouter.HandleFunc("/{id}", sourceController.DeleteSource).Methods(http.MethodDelete)
 	protectedRouter.HandleFunc("/{id}/ingest", sourceController.IngestSourceFeed).Methods(http.MethodPost)
+	protectedRouter.HandleFunc("/{id}/health", sourceController.GetSourceHealth).Methods(http.MethodGet)
+	protectedRouter.HandleFunc("/{id}/enable", sourceController.EnableSource).Methods(http.MethodPost)
 }
@@ -1,10 +1,30 @@
 package services
 
 import (
+	"errors"
+	"time"
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
 	"vuka-api/pkg/repository"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// saveAttempts is how many times a feed article's transaction is tried before giving up
+const saveAttempts = 3
+
+// ErrUnidentifiableArticle is returned for a feed item with neither a URL nor
+// a GUID, which could never be recognised when the feed is fetched again
+var ErrUnidentifiableArticle = errors.New("article has no URL or GUID to identify it by")
+
+// SaveOutcome describes what happened to an article saved from a feed
+type SaveOutcome int
+
+const (
+	ArticleUnchanged SaveOutcome = iota // A duplicate exists and its content is the same
+	ArticleCreated                      // No duplicate existed and the article was created
+	ArticleUpdated                      // The publisher changed the article and a revision was recorded
 )
 
 // ArticleService ...
@@ -19,28 +39,221 @@ func NewArticleService(repos *repository.Repositories) *ArticleService {
 
 // CreateArticle ...
 func (s *ArticleService) CreateArticle(article *db.Article) error {
+	if article.CanonicalUrl == "" {
+		article.CanonicalUrl = models.CanonicalURL(article.OriginalUrl)
+	}
 	return s.repos.Article.Create(article)
 }
 
-// CreateArticleIfNotExists ...
-func (s *ArticleService) CreateArticleIfNotExists(article *db.Article) (bool, error) {
-	// Check if article already exists by original URL
-	exists, err := s.repos.Article.ExistsByOriginalUrl(article.OriginalUrl)
+// SaveFromFeed creates the article together with its images and categories
+// unless one with the same GUID from the same source, or the same canonical URL,
+// already exists. When the existing article came from the same source and its
+// content has changed, it is updated and the change is recorded as a revision.
+//
+// Each attempt runs in a single transaction, so an article is never stored
+// without its images and categories. Because the duplicate check is part of
+// the transaction, retrying after a failure cannot create a second copy.
+func (s *ArticleService) SaveFromFeed(article *db.Article, categories []db.Category) (SaveOutcome, error) {
+	if article.CanonicalUrl == "" {
+		article.CanonicalUrl = models.CanonicalURL(article.OriginalUrl)
+	}
+	if article.ContentHash == "" {
+		article.ContentHash = models.ArticleContentHash(article.Title, article.Summary, article.ContentBody)
+	}
+	if !identifiable(article) {
+		return ArticleUnchanged, ErrUnidentifiableArticle
+	}
+
+	article.Categories = make([]*db.Category, len(categories))
+	for i := range categories {
+		article.Categories[i] = &categories[i]
+	}
+
+	var outcome SaveOutcome
+	var err error
+	for attempt := 1; attempt <= saveAttempts; attempt++ {
+		err = s.repos.Transaction(func(tx *repository.Repositories) error {
+			outcome, err = saveFromFeed(tx, article)
+			return err
+		})
+
+		// A concurrent run inserted the same story first and the unique
+		// indexes rejected this copy
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ArticleUnchanged, nil
+		}
+		if err == nil {
+			return outcome, nil
+		}
+		if attempt < saveAttempts {
+			time.Sleep(time.Duration(attempt) * 200 * time.Millisecond)
+		}
+	}
+
+	return ArticleUnchanged, err
+}
+
+func saveFromFeed(tx *repository.Repositories, article *db.Article) (SaveOutcome, error) {
+	existing, err := tx.Article.FindDuplicate(article.SourceID, article.GUID, article.CanonicalUrl, article.OriginalUrl)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Article.CreateWithAssociationsAndTransaction(tx.DB(), article); err != nil {
+			return ArticleUnchanged, err
+		}
+		return ArticleCreated, nil
+	}
+	if err != nil {
+		return ArticleUnchanged, err
+	}
+
+	// Syndicated copies from other sources never overwrite the original
+	if !sameSource(existing.SourceID, article.SourceID) {
+		return ArticleUnchanged, nil
+	}
+
+	article.ID = existing.ID
+	return reviseArticle(tx, existing, article)
+}
+
+// reviseArticle applies the feed's version of an article if its content hash
+// differs from the stored one, recording the previous content as a revision
+func reviseArticle(tx *repository.Repositories, existing, incoming *db.Article) (SaveOutcome, error) {
+	// Articles ingested before hashes were stored have been sanitized since,
+	// so hashing their stored content would never match the raw feed. Adopt
+	// the feed's hash as the baseline instead of recording a revision.
+	if existing.ContentHash == "" {
+		return ArticleUnchanged, tx.Article.Update(existing.ID, map[string]any{"content_hash": incoming.ContentHash})
+	}
+	if existing.ContentHash == incoming.ContentHash {
+		return ArticleUnchanged, nil
+	}
+
+	now := time.Now()
+	revision := &db.ArticleRevision{
+		ArticleID:       existing.ID,
+		PreviousTitle:   existing.Title,
+		PreviousSummary: existing.Summary,
+		TitleDiff:       models.DiffWords(existing.Title, incoming.Title),
+		SummaryDiff:     models.DiffWords(existing.Summary, incoming.Summary),
+		PreviousHash:    existing.ContentHash,
+		ContentHash:     incoming.ContentHash,
+		DetectedAt:      now,
+	}
+	// An extracted page is not the feed content the publisher changed, so
+	// diffing it against the feed's teaser would only show the extraction
+	if existing.ContentExtractedAt == nil {
+		revision.ContentDiff = models.DiffWords(existing.ContentBody, incoming.ContentBody)
+	}
+	if err := tx.Revision.Create(revision); err != nil {
+		return ArticleUnchanged, err
+	}
+
+	// The language is detected from the title and summary, so it follows them.
+	// The feed content replaces any extracted page until it is extracted again.
+	err := tx.Article.Update(existing.ID, map[string]any{
+		"title":                incoming.Title,
+		"summary":              incoming.Summary,
+		"summary_text":         incoming.SummaryText,
+		"content_body":         incoming.ContentBody,
+		"content_text":         incoming.ContentText,
+		"content_hash":         incoming.ContentHash,
+		"sim_hash":             incoming.SimHash,
+		"detected_language":    incoming.DetectedLanguage,
+		"language_confidence":  incoming.LanguageConfidence,
+		"content_extracted_at": nil,
+		"revision_count":       gorm.Expr("revision_count + 1"),
+		"revised_at":           now,
+	})
+	if err != nil {
+		return ArticleUnchanged, err
+	}
+
+	return ArticleUpdated, nil
+}
+
+// ApplyExtractedContent stores the full text extracted from an article's web page
+func (s *ArticleService) ApplyExtractedContent(article *db.Article, extracted *models.ExtractedArticle) error {
+	now := time.Now()
+	content := models.SanitizeHTML(extracted.HTML)
+	err := s.repos.Article.Update(article.ID, map[string]any{
+		"content_body":         content,
+		"content_text":         extracted.Text,
+		"content_extracted_at": now,
+	})
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	if exists {
-		return false, nil // Article exists, not created
+	article.ContentBody = content
+	article.ContentText = extracted.Text
+	article.ContentExtractedAt = &now
+	return nil
+}
+
+// AddMainImage stores imageURL as the main image of an article that has none
+func (s *ArticleService) AddMainImage(article *db.Article, imageURL string) error {
+	image := db.ArticleImage{
+		ArticleID: article.ID,
+		URL:       imageURL,
+		IsMain:    true,
+	}
+	if err := s.repos.Article.AddImage(&image); err != nil {
+		return err
 	}
 
-	// Article doesn't exist, create it
-	err = s.repos.Article.Create(article)
+	article.Images = append(article.Images, image)
+	return nil
+}
+
+// GetArticleRevisions returns the recorded revisions of an article, newest first
+func (s *ArticleService) GetArticleRevisions(id string) ([]db.ArticleRevision, error) {
+	articleId, err := uuid.Parse(id)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	if _, err := s.repos.Article.GetByID(articleId); err != nil {
+		return nil, err
 	}
+	return s.repos.Revision.GetByArticleID(articleId)
+}
 
-	return true, nil // Article created successfully
+// regionID validates a region assigned in an article update; null or an
+// empty string clears the article's region
+func (s *ArticleService) regionID(val any) (*string, error) {
+	if val == nil || val == "" {
+		return nil, nil
+	}
+	id, ok := val.(string)
+	if !ok {
+		return nil, ErrUnknownRegion
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrUnknownRegion
+	}
+	region, err := s.repos.Region.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUnknownRegion
+	}
+	if err != nil {
+		return nil, err
+	}
+	regionID := region.ID.String()
+	return &regionID, nil
+}
+
+// identifiable reports whether a feed article has a URL, or a GUID scoped to
+// its source, that a later fetch of the same item can be matched on
+func identifiable(article *db.Article) bool {
+	if article.OriginalUrl != "" || article.CanonicalUrl != "" {
+		return true
+	}
+	return article.SourceID != nil && article.GUID != ""
+}
+
+func sameSource(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
 }
 
 // GetArticleByID ...
@@ -67,6 +280,21 @@ func (s *ArticleService) GetAllArticlesPaginatedAndSearch(limit, offset int, sea
 	return s.repos.Article.GetAllWithRelationsPaginatedAndSearch(limit, offset, search)
 }
 
+// GetArticlesByQuery returns paginated articles with relations matching the query
+func (s *ArticleService) GetArticlesByQuery(limit, offset int, query models.ArticleQuery) ([]db.Article, int64, error) {
+	return s.repos.Article.GetAllWithRelationsByQuery(limit, offset, query)
+}
+
+// GetArticlesAfter returns a keyset-paginated page of articles matching the query
+func (s *ArticleService) GetArticlesAfter(limit int, query models.ArticleQuery) ([]db.Article, *models.Cursor, error) {
+	return s.repos.Article.GetAllWithRelationsAfter(limit, query)
+}
+
+// GetArticleFacets counts the articles matching the query per category and per source
+func (s *ArticleService) GetArticleFacets(query models.ArticleQuery) (*models.ArticleFacets, error) {
+	return s.repos.Article.GetFacetsByQuery(query)
+}
+
 // UpdateArticle ...
 func (s *ArticleService) UpdateArticle(id string, updates map[string]any) (*db.Article, error) {
 	articleId, err := uuid.Parse(id)
@@ -98,6 +326,7 @@ func (s *ArticleService) UpdateArticle(id string, updates map[string]any) (*db.A
 	delete(updates, "source")
 	delete(updates, "region")
 	delete(updates, "images")
+	delete(updates, "cluster")
 
 	// Convert camelCase JSON field names to snake_case database column names
 	if val, ok := updates["isFeatured"]; ok {
@@ -110,8 +339,12 @@ func (s *ArticleService) UpdateArticle(id string, updates map[string]any) (*db.A
 	}
 	if val, ok := updates["originalUrl"]; ok {
 		updates["original_url"] = val
+		if url, isString := val.(string); isString {
+			updates["canonical_url"] = models.CanonicalURL(url)
+		}
 		delete(updates, "originalUrl")
 	}
+	delete(updates, "canonicalUrl")
 	if val, ok := updates["publishedAt"]; ok {
 		updates["published_at"] = val
 		delete(updates, "publishedAt")
@@ -120,6 +353,18 @@ func (s *ArticleService) UpdateArticle(id string, updates map[string]any) (*db.A
 		updates["source_id"] = val
 		delete(updates, "sourceId")
 	}
+	for _, key := range []string{"regionId", "regionID"} {
+		val, ok := updates[key]
+		if !ok {
+			continue
+		}
+		regionID, err := s.regionID(val)
+		if err != nil {
+			return nil, err
+		}
+		updates["region_id"] = regionID
+		delete(updates, key)
+	}
 
 	err = s.repos.Article.Update(articleId, updates)
 	if err != nil {
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeArticleRepository records duplicate lookups; a stored article with an
+// empty URL would match any lookup on a blank URL
+type fakeArticleRepository struct {
+	contracts.ArticleRepository
+	lookups []string
+	created []*db.Article
+	updates []map[string]any
+}
+
+func (r *fakeArticleRepository) FindDuplicate(sourceID *uuid.UUID, guid, canonicalUrl, originalUrl string) (*db.Article, error) {
+	r.lookups = append(r.lookups, guid+"|"+canonicalUrl+"|"+originalUrl)
+	return &db.Article{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeArticleRepository) CreateWithAssociationsAndTransaction(tx *gorm.DB, article *db.Article) error {
+	r.created = append(r.created, article)
+	return nil
+}
+
+func (r *fakeArticleRepository) Update(id uuid.UUID, updates map[string]any) error {
+	r.updates = append(r.updates, updates)
+	return nil
+}
+
+type fakeRevisionRepository struct {
+	contracts.RevisionRepository
+	created []*db.ArticleRevision
+}
+
+func (r *fakeRevisionRepository) Create(revision *db.ArticleRevision) error {
+	r.created = append(r.created, revision)
+	return nil
+}
+
+func TestSaveFromFeed_RejectsArticlesWithoutLinkOrGUID(t *testing.T) {
+	articles := &fakeArticleRepository{}
+	service := NewArticleService(&repository.Repositories{Article: articles})
+	sourceID := uuid.New()
+
+	tests := []struct {
+		name    string
+		article *db.Article
+	}{
+		{name: "No link or GUID", article: &db.Article{Title: "Untitled", SourceID: &sourceID}},
+		{name: "GUID without a source", article: &db.Article{Title: "Ad hoc", GUID: "item-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SaveFromFeed(tt.article, nil)
+			if !errors.Is(err, ErrUnidentifiableArticle) {
+				t.Errorf("Expected ErrUnidentifiableArticle, got %v", err)
+			}
+		})
+	}
+	if len(articles.lookups) != 0 {
+		t.Errorf("Expected no duplicate lookups, got %v", articles.lookups)
+	}
+}
+
+func TestSaveFromFeed_GUIDOnlyArticle(t *testing.T) {
+	articles := &fakeArticleRepository{}
+	tx := &repository.Repositories{Article: articles}
+	sourceID := uuid.New()
+
+	article := &db.Article{Title: "Load shedding update", SourceID: &sourceID, GUID: "urn:news24:1234"}
+	outcome, err := saveFromFeed(tx, article)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if outcome != ArticleCreated || len(articles.created) != 1 {
+		t.Errorf("Expected the article to be created, got outcome %d", outcome)
+	}
+	if len(articles.lookups) != 1 || articles.lookups[0] != "urn:news24:1234||" {
+		t.Errorf("Expected a lookup by GUID only, got %v", articles.lookups)
+	}
+}
+
+func TestReviseArticle_LegacyArticleAdoptsFeedHash(t *testing.T) {
+	articles := &fakeArticleRepository{}
+	tx := &repository.Repositories{Article: articles}
+
+	// Stored before hashes existed, and sanitized since
+	existing := &db.Article{Title: "Budget speech", Summary: "<p>Tax changes</p>"}
+	incoming := &db.Article{Title: "Budget speech", Summary: "<p onclick=\"x()\">Tax changes</p>", ContentHash: "feed-hash"}
+
+	outcome, err := reviseArticle(tx, existing, incoming)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if outcome != ArticleUnchanged {
+		t.Errorf("Expected ArticleUnchanged, got %d", outcome)
+	}
+	if len(articles.updates) != 1 || articles.updates[0]["content_hash"] != "feed-hash" || len(articles.updates[0]) != 1 {
+		t.Errorf("Expected only the content hash to be stored, got %v", articles.updates)
+	}
+}
+
+func TestReviseArticle_ContentDiff(t *testing.T) {
+	extractedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		extractedAt *time.Time
+		expectDiff  bool
+	}{
+		{name: "Feed content", expectDiff: true},
+		{name: "Extracted page", extractedAt: &extractedAt, expectDiff: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles := &fakeArticleRepository{}
+			revisions := &fakeRevisionRepository{}
+			tx := &repository.Repositories{Article: articles, Revision: revisions}
+
+			existing := &db.Article{Title: "Budget speech", ContentBody: "<p>The full speech as published on the site</p>", ContentHash: "old", ContentExtractedAt: tt.extractedAt}
+			incoming := &db.Article{Title: "Budget speech: VAT unchanged", ContentBody: "<p>Teaser</p>", ContentHash: "new", DetectedLanguage: "en", LanguageConfidence: 0.8}
+
+			outcome, err := reviseArticle(tx, existing, incoming)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if outcome != ArticleUpdated || len(revisions.created) != 1 {
+				t.Fatalf("Expected a revision to be recorded, got outcome %d", outcome)
+			}
+			revision := revisions.created[0]
+			if revision.TitleDiff == "" {
+				t.Errorf("Expected a title diff")
+			}
+			if hasDiff := revision.ContentDiff != ""; hasDiff != tt.expectDiff {
+				t.Errorf("Expected content diff %t, got %q", tt.expectDiff, revision.ContentDiff)
+			}
+			if len(articles.updates) != 1 || articles.updates[0]["detected_language"] != "en" || articles.updates[0]["language_confidence"] != 0.8 {
+				t.Errorf("Expected the detected language to be updated, got %v", articles.updates)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+)
+
+// categoryMapperTTL bounds how long another API instance may keep using
+// mapping rules after an editor changes them
+const categoryMapperTTL = 5 * time.Minute
+
+// ErrInvalidCategoryGroup is returned when a group name or keyword is blank
+var ErrInvalidCategoryGroup = errors.New("category group name and keywords must not be blank")
+
+// ErrInvalidKeywordWeight is returned when a keyword's weight is outside 0 to 1
+var ErrInvalidKeywordWeight = errors.New("keyword weight must be between 0 and 1")
+
+// categoryMapperCache is shared by every CategoryMappingService so a change
+// made through one controller reaches the ingestion pipeline immediately
+var categoryMapperCache struct {
+	sync.RWMutex
+	mapper   *models.CategoryMapper
+	loadedAt time.Time
+}
+
+// CategoryMappingService manages the rules that group feed categories
+type CategoryMappingService struct {
+	repo contracts.CategoryMappingRepository
+}
+
+// NewCategoryMappingService creates a new CategoryMappingService.
+func NewCategoryMappingService(repo contracts.CategoryMappingRepository) *CategoryMappingService {
+	return &CategoryMappingService{repo: repo}
+}
+
+// Mapper returns the cached category mapper, loading the rules from the
+// database when they have not been loaded yet or have gone stale
+func (s *CategoryMappingService) Mapper() *models.CategoryMapper {
+	categoryMapperCache.RLock()
+	mapper, loadedAt := categoryMapperCache.mapper, categoryMapperCache.loadedAt
+	categoryMapperCache.RUnlock()
+
+	if mapper != nil && time.Since(loadedAt) < categoryMapperTTL {
+		return mapper
+	}
+
+	if err := s.Reload(); err != nil {
+		log.Printf("Failed to load category mapping rules: %v", err)
+		if mapper != nil {
+			return mapper
+		}
+		return models.NewCategoryMapper()
+	}
+
+	categoryMapperCache.RLock()
+	defer categoryMapperCache.RUnlock()
+	return categoryMapperCache.mapper
+}
+
+// Reload replaces the cached mapper with the rules currently in the database.
+// CATEGORY_MATCH_THRESHOLD overrides the confidence a group needs to be assigned.
+func (s *CategoryMappingService) Reload() error {
+	groups, err := s.repo.GetAllGroups()
+	if err != nil {
+		return err
+	}
+
+	mapperGroups := make([]models.CategoryGroup, 0, len(groups))
+	for _, group := range groups {
+		keywords := make([]models.WeightedKeyword, 0, len(group.Keywords))
+		for _, keyword := range group.Keywords {
+			keywords = append(keywords, models.WeightedKeyword{
+				Keyword:  keyword.Keyword,
+				Weight:   keyword.Weight,
+				Negative: keyword.Negative,
+			})
+		}
+		mapperGroups = append(mapperGroups, models.CategoryGroup{Name: group.Name, Weighted: keywords})
+	}
+
+	mapper := models.NewCategoryMapperWithGroups(mapperGroups)
+	if threshold, err := strconv.ParseFloat(os.Getenv("CATEGORY_MATCH_THRESHOLD"), 64); err == nil && threshold > 0 && threshold <= 1 {
+		mapper.SetThreshold(threshold)
+	}
+
+	categoryMapperCache.Lock()
+	categoryMapperCache.mapper = mapper
+	categoryMapperCache.loadedAt = time.Now()
+	categoryMapperCache.Unlock()
+	return nil
+}
+
+// GetGroups returns every category group with its keywords
+func (s *CategoryMappingService) GetGroups() ([]db.CategoryGroup, error) {
+	return s.repo.GetAllGroups()
+}
+
+// GetGroupByID returns a category group with its keywords
+func (s *CategoryMappingService) GetGroupByID(id string) (*db.CategoryGroup, error) {
+	groupID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetGroupByID(groupID)
+}
+
+// CreateGroup adds a category group and reloads the mapper
+func (s *CategoryMappingService) CreateGroup(group *db.CategoryGroup) error {
+	if err := normalizeGroup(group); err != nil {
+		return err
+	}
+	if err := s.repo.CreateGroup(group); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// UpdateGroup renames a group, replaces its keywords and reloads the mapper
+func (s *CategoryMappingService) UpdateGroup(group *db.CategoryGroup) error {
+	if err := normalizeGroup(group); err != nil {
+		return err
+	}
+	if _, err := s.repo.GetGroupByID(group.ID); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateGroup(group); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// DeleteGroup removes a group and its keywords and reloads the mapper
+func (s *CategoryMappingService) DeleteGroup(id string) error {
+	groupID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteGroup(groupID); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// AddKeyword adds a keyword to a group and reloads the mapper
+func (s *CategoryMappingService) AddKeyword(groupID string, keyword *db.CategoryKeyword) error {
+	id, err := uuid.Parse(groupID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.repo.GetGroupByID(id); err != nil {
+		return err
+	}
+
+	keyword.GroupID = id
+	if err := normalizeKeyword(keyword); err != nil {
+		return err
+	}
+	if err := s.repo.AddKeyword(keyword); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// DeleteKeyword removes a keyword from a group and reloads the mapper
+func (s *CategoryMappingService) DeleteKeyword(groupID, keywordID string) error {
+	gid, err := uuid.Parse(groupID)
+	if err != nil {
+		return err
+	}
+	kid, err := uuid.Parse(keywordID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteKeyword(gid, kid); err != nil {
+		return err
+	}
+	return s.Reload()
+}
+
+// normalizeGroup trims the name, lowercases keywords and drops duplicates
+func normalizeGroup(group *db.CategoryGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return ErrInvalidCategoryGroup
+	}
+
+	seen := make(map[string]bool)
+	keywords := make([]db.CategoryKeyword, 0, len(group.Keywords))
+	for _, keyword := range group.Keywords {
+		if err := normalizeKeyword(&keyword); err != nil {
+			return err
+		}
+		if seen[keyword.Keyword] {
+			continue
+		}
+		seen[keyword.Keyword] = true
+		keywords = append(keywords, keyword)
+	}
+	group.Keywords = keywords
+	return nil
+}
+
+// normalizeKeyword lowercases a keyword and defaults its weight to 1
+func normalizeKeyword(keyword *db.CategoryKeyword) error {
+	keyword.Keyword = strings.ToLower(strings.TrimSpace(keyword.Keyword))
+	if keyword.Keyword == "" {
+		return ErrInvalidCategoryGroup
+	}
+	if keyword.Weight < 0 || keyword.Weight > 1 {
+		return ErrInvalidKeywordWeight
+	}
+	if keyword.Weight == 0 {
+		keyword.Weight = 1
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// categoryClassifierName identifies the category model among stored classifiers
+	categoryClassifierName = "category"
+	// classifierMinConfidence is the probability a predicted category needs to
+	// be assigned. The probabilities sum to 1, so above 0.5 at most one
+	// category is ever inferred for an article.
+	classifierMinConfidence = 0.6
+	// classifierMinDocuments is the smallest training set worth storing a model for
+	classifierMinDocuments = 50
+	// classifierMinWordCount drops rarer words from the trained vocabulary
+	classifierMinWordCount = 2
+	// classifierTTL bounds how long an instance keeps using a model after a retrain
+	classifierTTL = 30 * time.Minute
+)
+
+// ErrNotEnoughTrainingData is returned when too few categorized articles exist to train on
+var ErrNotEnoughTrainingData = errors.New("not enough categorized articles to train the classifier")
+
+// classifierCache holds the stored category model shared by every ClassifierService
+var classifierCache struct {
+	sync.RWMutex
+	model    *models.TextClassifier // nil when no model has been trained
+	loadedAt time.Time
+}
+
+// TrainingResult summarises a classifier training run
+type TrainingResult struct {
+	Documents int            `json:"documents"`
+	Classes   map[string]int `json:"classes"` // Training documents per category
+	TrainedAt time.Time      `json:"trainedAt"`
+}
+
+// ClassifierService infers categories for articles whose feeds provide none
+type ClassifierService struct {
+	repos          *repository.Repositories
+	mappingService *CategoryMappingService
+}
+
+// NewClassifierService creates a new ClassifierService.
+func NewClassifierService(repos *repository.Repositories, mappingService *CategoryMappingService) *ClassifierService {
+	return &ClassifierService{repos: repos, mappingService: mappingService}
+}
+
+// InferCategories predicts the category group of an article from its title,
+// summary and content with the trained model, returning a single label when
+// the model is confident. Until a model has been trained, the category
+// mapping keywords are matched against the title instead.
+func (s *ClassifierService) InferCategories(article *db.Article) []string {
+	if model := s.model(); model != nil {
+		text := article.Title + " " + article.SummaryText + " " + article.ContentText
+		return model.Classify(text, classifierMinConfidence, 1)
+	}
+	return s.mappingService.Mapper().MapCategories([]string{article.Title})
+}
+
+// Train builds a new model from every article whose categories came from its
+// feed, stores it and starts using it. Only categories that are current
+// mapping groups are learned, so removed groups are never predicted.
+func (s *ClassifierService) Train() (*TrainingResult, error) {
+	groups := make(map[string]bool)
+	for _, name := range s.mappingService.Mapper().Groups() {
+		groups[name] = true
+	}
+
+	classifier := models.NewTextClassifier()
+	err := s.repos.Article.EachCategorized(500, func(articles []db.Article) error {
+		for _, article := range articles {
+			var labels []string
+			for _, category := range article.Categories {
+				if groups[category.Name] {
+					labels = append(labels, category.Name)
+				}
+			}
+			classifier.Train(article.Title+" "+article.SummaryText+" "+article.ContentText, labels)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load training articles: %w", err)
+	}
+	if classifier.Documents < classifierMinDocuments || len(classifier.Classes) < 2 {
+		return nil, fmt.Errorf("%w: %d articles in %d categories", ErrNotEnoughTrainingData, classifier.Documents, len(classifier.Classes))
+	}
+	classifier.Prune(classifierMinWordCount)
+
+	data, err := json.Marshal(classifier)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TrainingResult{
+		Documents: classifier.Documents,
+		Classes:   make(map[string]int, len(classifier.Classes)),
+		TrainedAt: time.Now(),
+	}
+	for name, class := range classifier.Classes {
+		result.Classes[name] = class.Documents
+	}
+
+	err = s.repos.Classifier.Create(&db.ClassifierModel{
+		Name:      categoryClassifierName,
+		Data:      string(data),
+		Documents: result.Documents,
+		Classes:   len(result.Classes),
+		TrainedAt: result.TrainedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store classifier: %w", err)
+	}
+
+	classifierCache.Lock()
+	classifierCache.model = classifier
+	classifierCache.loadedAt = time.Now()
+	classifierCache.Unlock()
+
+	return result, nil
+}
+
+// model returns the cached classifier, loading the newest stored model when
+// the cache is empty or stale
+func (s *ClassifierService) model() *models.TextClassifier {
+	classifierCache.RLock()
+	model, loadedAt := classifierCache.model, classifierCache.loadedAt
+	classifierCache.RUnlock()
+
+	if !loadedAt.IsZero() && time.Since(loadedAt) < classifierTTL {
+		return model
+	}
+
+	stored, err := s.repos.Classifier.GetLatest(categoryClassifierName)
+	if err == nil {
+		loaded := models.NewTextClassifier()
+		if err = json.Unmarshal([]byte(stored.Data), loaded); err == nil {
+			model = loaded
+		}
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("Failed to load category classifier: %v", err)
+	}
+
+	classifierCache.Lock()
+	classifierCache.model = model
+	classifierCache.loadedAt = time.Now()
+	classifierCache.Unlock()
+	return model
+}
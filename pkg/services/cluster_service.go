@@ -0,0 +1,110 @@
+package services
+
+import (
+	"time"
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository"
+
+	"github.com/google/uuid"
+)
+
+// clusterWindow bounds how far back a new article is compared against
+const clusterWindow = 72 * time.Hour
+
+// ClusterService groups near-identical articles into story clusters
+type ClusterService struct {
+	repos *repository.Repositories
+}
+
+// NewClusterService creates a new ClusterService.
+func NewClusterService(repos *repository.Repositories) *ClusterService {
+	return &ClusterService{repos: repos}
+}
+
+// AssignCluster compares a newly created article against recent articles and
+// adds it to the story cluster of its nearest match, creating the cluster if
+// the match was not clustered yet. The earliest published article represents
+// the cluster. Lookup and assignment hold a lock so concurrent ingestion never
+// creates two clusters for one story.
+func (s *ClusterService) AssignCluster(article *db.Article) error {
+	if article.SimHash == 0 {
+		article.SimHash = int64(models.SimHash(article.Title + " " + article.Summary))
+		if article.SimHash == 0 {
+			return nil
+		}
+		if err := s.repos.Article.Update(article.ID, map[string]any{"sim_hash": article.SimHash}); err != nil {
+			return err
+		}
+	}
+
+	return s.repos.Transaction(func(tx *repository.Repositories) error {
+		if err := tx.Cluster.Lock(); err != nil {
+			return err
+		}
+		return assignCluster(tx, article)
+	})
+}
+
+func assignCluster(tx *repository.Repositories, article *db.Article) error {
+	since := article.PublishedAt.Add(-clusterWindow)
+	candidates, err := tx.Cluster.GetCandidates(since, article.ID)
+	if err != nil {
+		return err
+	}
+
+	var match *db.Article
+	bestDistance := models.NearDuplicateDistance + 1
+	for i := range candidates {
+		candidate := &candidates[i]
+		if !models.IsNearDuplicate(uint64(article.SimHash), uint64(candidate.SimHash)) {
+			continue
+		}
+		if distance := models.HammingDistance(uint64(article.SimHash), uint64(candidate.SimHash)); distance < bestDistance {
+			match, bestDistance = candidate, distance
+		}
+	}
+	if match == nil {
+		return nil
+	}
+
+	members := []uuid.UUID{article.ID}
+	clusterID := match.ClusterID
+	if clusterID == nil {
+		// RefreshStats settles the representative once both articles are members
+		cluster := &db.StoryCluster{RepresentativeID: match.ID, LastSeenAt: time.Now()}
+		if err := tx.Cluster.Create(cluster); err != nil {
+			return err
+		}
+		clusterID = &cluster.ID
+		members = append(members, match.ID)
+	}
+
+	if err := tx.Cluster.AssignArticles(*clusterID, members...); err != nil {
+		return err
+	}
+	if err := tx.Cluster.RefreshStats(*clusterID); err != nil {
+		return err
+	}
+
+	article.ClusterID = clusterID
+	return nil
+}
+
+// GetRelatedArticles returns the other articles in the same story cluster
+func (s *ClusterService) GetRelatedArticles(id string) ([]db.Article, error) {
+	articleID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := s.repos.Article.GetByID(articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article.ClusterID == nil {
+		return []db.Article{}, nil
+	}
+
+	return s.repos.Cluster.GetMembers(*article.ClusterID, article.ID)
+}
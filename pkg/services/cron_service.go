@@ -1,28 +1,28 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
+	"vuka-api/pkg/models/ingestion"
 
 	"github.com/robfig/cron/v3"
 )
 
 type CronService struct {
 	cron              *cron.Cron
-	rssService        *RssService
-	sourceService     *SourceService
+	ingestionService  *IngestionService
 	newsletterService *NewsletterService
 }
 
-func NewCronService(rssService *RssService, sourceService *SourceService, newsletterService *NewsletterService) *CronService {
+func NewCronService(ingestionService *IngestionService, newsletterService *NewsletterService) *CronService {
 	// Create cron with second precision and logging
 	c := cron.New(cron.WithSeconds(), cron.WithLogger(cron.VerbosePrintfLogger(log.New(log.Writer(), "CRON: ", log.LstdFlags))))
 
 	return &CronService{
 		cron:              c,
-		rssService:        rssService,
-		sourceService:     sourceService,
+		ingestionService:  ingestionService,
 		newsletterService: newsletterService,
 	}
 }
@@ -64,53 +64,32 @@ func (s *CronService) ScheduleRSSIngestionDaily(hour, minute int) error {
 	return nil
 }
 
-// ingestAllRSSFeeds fetches all sources and ingests their RSS feeds
+// ingestAllRSSFeeds is the scheduled entry point for IngestAllRSSFeeds
 func (s *CronService) ingestAllRSSFeeds() {
 	log.Println("Starting scheduled RSS feed ingestion...")
-	start := time.Now()
-
-	sources, err := s.sourceService.GetAllSources()
-	if err != nil {
-		log.Printf("Failed to get sources: %v", err)
-		return
+	if _, err := s.IngestAllRSSFeeds(context.Background(), ingestion.TriggerScheduled); err != nil {
+		log.Printf("Scheduled RSS ingestion failed: %v", err)
 	}
+}
 
-	if len(sources) == 0 {
-		log.Println("No sources found to ingest")
-		return
-	}
-
-	log.Printf("Found %d sources to process", len(sources))
-
-	successCount := 0
-	errorCount := 0
-
-	for _, source := range sources {
-		if source.RssFeedUrl == "" {
-			log.Printf("Skipping source '%s' - no RSS feed URL", source.Name)
-			continue
-		}
-
-		log.Printf("Ingesting RSS feed for source: %s (%s)", source.Name, source.RssFeedUrl)
-
-		err := s.rssService.IngestRSSFeedWithSource(source.RssFeedUrl, &source.ID)
-		if err != nil {
-			log.Printf("Failed to ingest RSS feed for source '%s': %v", source.Name, err)
-			errorCount++
-		} else {
-			log.Printf("Successfully ingested RSS feed for source: %s", source.Name)
-			successCount++
-		}
+// IngestAllRSSFeeds fetches all sources through the worker pool and returns the run summary
+func (s *CronService) IngestAllRSSFeeds(ctx context.Context, trigger ingestion.Trigger) (*ingestion.RunSummary, error) {
+	summary, err := s.ingestionService.IngestAll(ctx, trigger)
+	if summary != nil {
+		log.Printf("RSS ingestion completed in %dms. Success: %d, Not modified: %d, Errors: %d, Skipped: %d, New articles: %d",
+			summary.DurationMs, summary.Succeeded, summary.NotModified, summary.Failed, summary.Skipped, summary.ItemsNew)
 	}
-
-	duration := time.Since(start)
-	log.Printf("RSS ingestion completed in %v. Success: %d, Errors: %d", duration, successCount, errorCount)
+	return summary, err
 }
 
 // TriggerRSSIngestionNow manually triggers RSS ingestion for all sources
 func (s *CronService) TriggerRSSIngestionNow() {
 	log.Println("Manually triggering RSS feed ingestion...")
-	go s.ingestAllRSSFeeds()
+	go func() {
+		if _, err := s.IngestAllRSSFeeds(context.Background(), ingestion.TriggerManual); err != nil {
+			log.Printf("Manual RSS ingestion failed: %v", err)
+		}
+	}()
 }
 
 // ScheduleNewsletterWeekly schedules newsletter to be sent weekly
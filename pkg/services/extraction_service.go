@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"vuka-api/pkg/models"
+)
+
+// maxArticlePageSize caps how much of an article page is read for extraction
+const maxArticlePageSize = 5 << 20
+
+// maxLeadImagePageSize is how much of a page is read looking for its og:image.
+// Meta tags sit in the head, so the start of the page is enough.
+const maxLeadImagePageSize = 256 << 10
+
+// ExtractionService fetches article pages and extracts their main content
+type ExtractionService struct {
+	client *http.Client
+}
+
+// NewExtractionService creates a new ExtractionService.
+func NewExtractionService() *ExtractionService {
+	return &ExtractionService{
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ExtractFromURL downloads the page at pageURL and returns its article content
+func (s *ExtractionService) ExtractFromURL(ctx context.Context, pageURL string) (*models.ExtractedArticle, error) {
+	body, finalURL, err := s.fetchPage(ctx, pageURL, maxArticlePageSize)
+	if err != nil {
+		return nil, err
+	}
+	return models.ExtractArticle(body, finalURL)
+}
+
+// FetchLeadImage returns the og:image or twitter:image declared by the page
+// at pageURL, or an empty string when it declares none
+func (s *ExtractionService) FetchLeadImage(ctx context.Context, pageURL string) (string, error) {
+	body, finalURL, err := s.fetchPage(ctx, pageURL, maxLeadImagePageSize)
+	if err != nil {
+		return "", err
+	}
+	return models.ExtractPageImage(body, finalURL), nil
+}
+
+// fetchPage downloads up to limit bytes of an HTML page and returns it with
+// the final URL after redirects, against which relative links resolve
+func (s *ExtractionService) fetchPage(ctx context.Context, pageURL string, limit int64) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build article request: %w", err)
+	}
+	req.Header.Set("User-Agent", "VukaFeedFetcher/1.0")
+	req.Header.Set("Accept", "text/html, application/xhtml+xml;q=0.9")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch article page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", "", fmt.Errorf("failed to fetch article page: unexpected status %s", resp.Status)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "html") {
+		return "", "", fmt.Errorf("article page is not HTML: %s", contentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read article page: %w", err)
+	}
+
+	return string(body), resp.Request.URL.String(), nil
+}
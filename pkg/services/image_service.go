@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository"
+	"vuka-api/pkg/storage"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxImageSize caps how much of a publisher's image is downloaded
+	maxImageSize = 10 << 20
+	// maxImagePixels caps the images decoded for thumbnails; a small file can
+	// declare enormous dimensions and exhaust memory once decoded
+	maxImagePixels = 40_000_000
+	// ThumbnailWidth is the width of the thumbnails served by /media/{id}?size=thumb
+	ThumbnailWidth   = 400
+	thumbnailQuality = 80
+)
+
+// imageExtensions maps the image types we store to their file extensions.
+// SVG is deliberately absent: it can carry scripts and we serve from our own origin.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+}
+
+// MediaFile is a stored image ready to be served
+type MediaFile struct {
+	Body        io.ReadCloser
+	ContentType string
+	ETag        string
+	ModTime     time.Time
+}
+
+// ImageService copies article images into media storage and serves them
+type ImageService struct {
+	repos   *repository.Repositories
+	storage storage.Storage
+	client  *http.Client
+}
+
+// NewImageService creates a new ImageService.
+func NewImageService(repos *repository.Repositories, mediaStorage storage.Storage) *ImageService {
+	return &ImageService{
+		repos:   repos,
+		storage: mediaStorage,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// StoreArticleImages downloads every image of a newly saved article. Images
+// that fail to download keep being served from their original URL.
+func (s *ImageService) StoreArticleImages(ctx context.Context, article *db.Article) {
+	for i := range article.Images {
+		if err := s.StoreImage(ctx, &article.Images[i]); err != nil {
+			log.Printf("Failed to store image %s for article '%s': %v", article.Images[i].URL, article.Title, err)
+		}
+	}
+}
+
+// StoreImage downloads an image, saves it with a resized thumbnail and records
+// its dimensions and content type. Images that are already stored are skipped.
+func (s *ImageService) StoreImage(ctx context.Context, img *db.ArticleImage) error {
+	if img.StoredAt != nil {
+		return nil
+	}
+
+	body, err := s.download(ctx, img.URL)
+	if err != nil {
+		return err
+	}
+
+	contentType := http.DetectContentType(body)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return fmt.Errorf("unsupported image type %s", contentType)
+	}
+
+	key := fmt.Sprintf("images/%s/%s%s", img.ArticleID, img.ID, ext)
+	if err := s.storage.Put(ctx, key, bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("failed to store image: %w", err)
+	}
+
+	now := time.Now()
+	updates := map[string]any{
+		"storage_key":  key,
+		"content_type": contentType,
+		"byte_size":    int64(len(body)),
+		"stored_at":    now,
+	}
+
+	// WebP has no decoder in the standard library; it is stored without a thumbnail.
+	// The header is read first so oversized images are never decoded.
+	if config, _, err := image.DecodeConfig(bytes.NewReader(body)); err == nil {
+		updates["width"], updates["height"] = config.Width, config.Height
+
+		if config.Width > ThumbnailWidth {
+			if config.Width*config.Height > maxImagePixels {
+				log.Printf("Skipping thumbnail for image %s: %dx%d pixels is over the limit", img.URL, config.Width, config.Height)
+			} else if err := s.storeThumbnail(ctx, img, body); err != nil {
+				log.Printf("Failed to store thumbnail for image %s: %v", img.URL, err)
+			} else {
+				updates["thumbnail_key"] = thumbnailKey(img)
+			}
+		}
+	}
+
+	if err := s.repos.Image.Update(img.ID, updates); err != nil {
+		return fmt.Errorf("failed to record stored image: %w", err)
+	}
+
+	img.StorageKey = key
+	img.ContentType = contentType
+	img.ByteSize = int64(len(body))
+	img.StoredAt = &now
+	if width, ok := updates["width"].(int); ok {
+		img.Width, img.Height = width, updates["height"].(int)
+	}
+	if key, ok := updates["thumbnail_key"].(string); ok {
+		img.ThumbnailKey = key
+	}
+	return nil
+}
+
+// OpenMedia opens the stored copy of an image, or its thumbnail when one
+// exists and thumbnail is set. When no copy is stored the file is nil and the
+// image's original URL is returned instead.
+func (s *ImageService) OpenMedia(ctx context.Context, id string, thumbnail bool) (*MediaFile, string, error) {
+	imageID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, "", err
+	}
+	img, err := s.repos.Image.GetByID(imageID)
+	if err != nil {
+		return nil, "", err
+	}
+	if img.StoredAt == nil || img.StorageKey == "" {
+		return nil, img.URL, nil
+	}
+
+	key, contentType := img.StorageKey, img.ContentType
+	if thumbnail && img.ThumbnailKey != "" {
+		key, contentType = img.ThumbnailKey, "image/jpeg"
+	}
+
+	body, err := s.storage.Open(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Stored image %s is missing from media storage, serving original URL", key)
+		return nil, img.URL, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &MediaFile{
+		Body:        body,
+		ContentType: contentType,
+		ETag:        `"` + strings.ReplaceAll(key, "/", "-") + `"`,
+		ModTime:     *img.StoredAt,
+	}, img.URL, nil
+}
+
+func (s *ImageService) download(ctx context.Context, imageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build image request: %w", err)
+	}
+	req.Header.Set("User-Agent", "VukaFeedFetcher/1.0")
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch image: unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength > maxImageSize {
+		return nil, fmt.Errorf("image is too large: %d bytes", resp.ContentLength)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(body) > maxImageSize {
+		return nil, fmt.Errorf("image is larger than %d bytes", maxImageSize)
+	}
+	return body, nil
+}
+
+func thumbnailKey(img *db.ArticleImage) string {
+	return fmt.Sprintf("images/%s/%s_thumb.jpg", img.ArticleID, img.ID)
+}
+
+// storeThumbnail decodes an image, resizes it to ThumbnailWidth and stores it
+// as a JPEG, flattening any transparency onto white
+func (s *ImageService) storeThumbnail(ctx context.Context, img *db.ArticleImage, body []byte) error {
+	src, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	resized := models.ResizeImage(src, ThumbnailWidth)
+	flattened := image.NewRGBA(resized.Bounds())
+	draw.Draw(flattened, flattened.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), resized, resized.Bounds().Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flattened, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return err
+	}
+	return s.storage.Put(ctx, thumbnailKey(img), &buf)
+}
@@ -0,0 +1,461 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/models/ingestion"
+	"vuka-api/pkg/repository/contracts"
+
+	"github.com/google/uuid"
+)
+
+// healthWindow is the number of recent attempts considered when reporting source health
+const healthWindow = 20
+
+// ErrIngestionInProgress is returned when a run is requested while another is active
+var ErrIngestionInProgress = errors.New("an ingestion run is already in progress")
+
+// IngestionConfig controls how feeds are fetched during a run
+type IngestionConfig struct {
+	Workers        int           // Feeds fetched concurrently across all hosts
+	PerHostLimit   int           // Feeds fetched concurrently from a single host
+	RequestTimeout time.Duration // Upper bound for downloading one feed; its items are processed outside it
+	MaxFailures    int           // Consecutive failures before a source is disabled
+	BackoffBase    time.Duration // Delay after the first failure, doubled for each one after
+	BackoffMax     time.Duration // Upper bound on the delay between failing fetches
+}
+
+// LoadIngestionConfig reads INGEST_WORKERS, INGEST_PER_HOST_LIMIT,
+// INGEST_REQUEST_TIMEOUT, INGEST_MAX_FAILURES, INGEST_BACKOFF_BASE and
+// INGEST_BACKOFF_MAX (durations such as "45s"), falling back to defaults
+func LoadIngestionConfig() IngestionConfig {
+	config := IngestionConfig{
+		Workers:        8,
+		PerHostLimit:   2,
+		RequestTimeout: 60 * time.Second,
+		MaxFailures:    10,
+		BackoffBase:    time.Hour,
+		BackoffMax:     24 * time.Hour,
+	}
+
+	if n, err := strconv.Atoi(os.Getenv("INGEST_WORKERS")); err == nil && n > 0 {
+		config.Workers = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("INGEST_PER_HOST_LIMIT")); err == nil && n > 0 {
+		config.PerHostLimit = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("INGEST_REQUEST_TIMEOUT")); err == nil && d > 0 {
+		config.RequestTimeout = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("INGEST_MAX_FAILURES")); err == nil && n > 0 {
+		config.MaxFailures = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("INGEST_BACKOFF_BASE")); err == nil && d > 0 {
+		config.BackoffBase = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("INGEST_BACKOFF_MAX")); err == nil && d > 0 {
+		config.BackoffMax = d
+	}
+
+	return config
+}
+
+// IngestionService runs feed ingestion for many sources through a bounded worker pool
+type IngestionService struct {
+	repo          contracts.IngestionRepository
+	rssService    *RssService
+	sourceService *SourceService
+	config        IngestionConfig
+	running       atomic.Bool
+}
+
+// NewIngestionService creates a new IngestionService.
+func NewIngestionService(repo contracts.IngestionRepository, rssService *RssService, sourceService *SourceService, config IngestionConfig) *IngestionService {
+	return &IngestionService{
+		repo:          repo,
+		rssService:    rssService,
+		sourceService: sourceService,
+		config:        config,
+	}
+}
+
+// IngestAll ingests every source that is enabled and not backing off, and
+// returns a summary of the run
+func (s *IngestionService) IngestAll(ctx context.Context, trigger ingestion.Trigger) (*ingestion.RunSummary, error) {
+	sources, err := s.sourceService.GetAllSources()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	due := make([]db.Source, 0, len(sources))
+	for _, source := range sources {
+		if source.IsDue(now) {
+			due = append(due, source)
+		}
+	}
+	if deferred := len(sources) - len(due); deferred > 0 {
+		log.Printf("Deferring %d disabled or backed-off sources", deferred)
+	}
+
+	return s.IngestSources(ctx, trigger, due)
+}
+
+// IngestSources ingests the given sources concurrently. At most config.Workers
+// feeds are in flight, and at most config.PerHostLimit against the same host.
+// The run and every source attempt are persisted as they complete.
+func (s *IngestionService) IngestSources(ctx context.Context, trigger ingestion.Trigger, sources []db.Source) (*ingestion.RunSummary, error) {
+	if !s.running.CompareAndSwap(false, true) {
+		return nil, ErrIngestionInProgress
+	}
+	defer s.running.Store(false)
+
+	summary := &ingestion.RunSummary{Trigger: trigger, StartedAt: time.Now()}
+	run := &db.IngestionRun{Trigger: string(trigger), StartedAt: summary.StartedAt}
+	if err := s.repo.CreateRun(run); err != nil {
+		log.Printf("Failed to record ingestion run: %v", err)
+	} else {
+		summary.RunID = &run.ID
+	}
+
+	jobs := make(chan db.Source)
+	results := make(chan ingestion.SourceResult)
+	limiter := newHostLimiter(s.config.PerHostLimit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for source := range jobs {
+				results <- s.ingestOne(ctx, limiter, source)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, source := range interleaveByHost(sources) {
+			select {
+			case jobs <- source:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		summary.Add(result)
+		s.recordAttempt(summary.RunID, result)
+	}
+
+	summary.FinishedAt = time.Now()
+	summary.DurationMs = summary.FinishedAt.Sub(summary.StartedAt).Milliseconds()
+
+	if summary.RunID != nil {
+		s.finishRun(run, summary, ctx.Err())
+	}
+	return summary, ctx.Err()
+}
+
+// IngestSource ingests a single source outside of a run and records the attempt
+func (s *IngestionService) IngestSource(ctx context.Context, source db.Source) ingestion.SourceResult {
+	result := s.ingestOne(ctx, newHostLimiter(1), source)
+	s.recordAttempt(nil, result)
+	return result
+}
+
+// GetRuns returns ingestion runs, newest first
+func (s *IngestionService) GetRuns(limit, offset int) ([]db.IngestionRun, int64, error) {
+	return s.repo.GetRunsPaginated(limit, offset)
+}
+
+// GetRunByID returns a run together with its per-source attempts
+func (s *IngestionService) GetRunByID(id string) (*db.IngestionRun, error) {
+	runID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetRunByID(runID)
+}
+
+// GetSourceHealth summarises the most recent attempts for a source
+func (s *IngestionService) GetSourceHealth(sourceID string) (*ingestion.SourceHealth, error) {
+	source, err := s.sourceService.GetSourceByID(sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.repo.GetRecentAttemptsBySource(source.ID, healthWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &ingestion.SourceHealth{
+		SourceID:       source.ID,
+		SourceName:     source.Name,
+		FeedURL:        source.RssFeedUrl,
+		Status:         ingestion.HealthNeverFetched,
+		NextFetchAt:    source.NextFetchAt,
+		DisabledAt:     source.DisabledAt,
+		DisabledReason: source.DisabledReason,
+		RecentAttempts: attempts,
+	}
+	if source.Disabled {
+		health.Status = ingestion.HealthDisabled
+	}
+	if len(attempts) == 0 {
+		return health, nil
+	}
+
+	health.LastAttemptAt = &attempts[0].StartedAt
+	counting := true
+	healthy := 0
+	for _, attempt := range attempts {
+		if attempt.Status == string(ingestion.StatusFailed) {
+			if counting {
+				health.ConsecutiveFailures++
+				if health.LastError == "" {
+					health.LastError = attempt.Error
+				}
+			}
+			continue
+		}
+		counting = false
+		healthy++
+	}
+	health.SuccessRate = float64(healthy) / float64(len(attempts))
+
+	if !source.Disabled {
+		health.Status = ingestion.HealthHealthy
+		if health.ConsecutiveFailures > 0 {
+			health.Status = ingestion.HealthFailing
+		}
+	}
+
+	lastSuccess, err := s.repo.GetLastAttemptBySourceAndStatus(source.ID, string(ingestion.StatusSuccess))
+	if err == nil {
+		health.LastSuccessAt = &lastSuccess.StartedAt
+	}
+
+	return health, nil
+}
+
+// recordAttempt persists a source result; failures are logged so they never abort a run
+func (s *IngestionService) recordAttempt(runID *uuid.UUID, result ingestion.SourceResult) {
+	attempt := &db.IngestionAttempt{
+		RunID:          runID,
+		SourceID:       result.SourceID,
+		FeedUrl:        result.FeedURL,
+		Status:         string(result.Status),
+		HttpStatus:     result.StatusCode,
+		ItemsSeen:      result.ItemsSeen,
+		ItemsNew:       result.ItemsNew,
+		ItemsDuplicate: result.ItemsDuplicate,
+		ItemsUpdated:   result.ItemsUpdated,
+		ItemsFailed:    result.ItemsFailed,
+		DatesRescued:   result.DatesRescued,
+		StartedAt:      result.StartedAt,
+		DurationMs:     result.DurationMs,
+		Error:          result.Error,
+	}
+	if err := s.repo.CreateAttempt(attempt); err != nil {
+		log.Printf("Failed to record ingestion attempt for source '%s': %v", result.SourceName, err)
+	}
+}
+
+// trackFailures updates the failure count of a source after an attempt. Failed
+// sources back off exponentially and are disabled after config.MaxFailures.
+func (s *IngestionService) trackFailures(source *db.Source, result ingestion.SourceResult) {
+	var updates map[string]any
+
+	switch result.Status {
+	case ingestion.StatusSuccess, ingestion.StatusNotModified:
+		if source.ConsecutiveFailures == 0 && source.NextFetchAt == nil {
+			return
+		}
+		updates = map[string]any{
+			"consecutive_failures": 0,
+			"next_fetch_at":        nil,
+			"last_error":           "",
+		}
+	case ingestion.StatusFailed:
+		failures := source.ConsecutiveFailures + 1
+		nextFetchAt := time.Now().Add(ingestion.Backoff(failures, s.config.BackoffBase, s.config.BackoffMax))
+		updates = map[string]any{
+			"consecutive_failures": failures,
+			"next_fetch_at":        nextFetchAt,
+			"last_error":           result.Error,
+		}
+		if failures >= s.config.MaxFailures && !source.Disabled {
+			reason := fmt.Sprintf("Disabled after %d consecutive failures: %s", failures, result.Error)
+			log.Printf("Disabling source '%s': %s", source.Name, reason)
+			updates["disabled"] = true
+			updates["disabled_at"] = time.Now()
+			updates["disabled_reason"] = reason
+		}
+	default:
+		return
+	}
+
+	if err := s.sourceService.RecordFetch(source.ID.String(), updates); err != nil {
+		log.Printf("Failed to update failure state for source '%s': %v", source.Name, err)
+	}
+}
+
+// finishRun copies the summary totals onto the persisted run
+func (s *IngestionService) finishRun(run *db.IngestionRun, summary *ingestion.RunSummary, runErr error) {
+	run.FinishedAt = &summary.FinishedAt
+	run.DurationMs = summary.DurationMs
+	run.SourcesTotal = summary.SourcesTotal
+	run.Succeeded = summary.Succeeded
+	run.NotModified = summary.NotModified
+	run.Failed = summary.Failed
+	run.Skipped = summary.Skipped
+	run.ItemsNew = summary.ItemsNew
+	run.ItemsDuplicate = summary.ItemsDuplicate
+	run.ItemsUpdated = summary.ItemsUpdated
+	run.ItemsFailed = summary.ItemsFailed
+	if runErr != nil {
+		run.Error = runErr.Error()
+	}
+	if err := s.repo.UpdateRun(run); err != nil {
+		log.Printf("Failed to update ingestion run %s: %v", run.ID, err)
+	}
+}
+
+// ingestOne fetches a single source, honouring the per-host limit and request
+// timeout. Only the fetch and parse decide the source's health; items that fail
+// or run out of time while being enriched are logged against the article.
+func (s *IngestionService) ingestOne(ctx context.Context, limiter *hostLimiter, source db.Source) ingestion.SourceResult {
+	result := ingestion.SourceResult{
+		SourceID:   source.ID,
+		SourceName: source.Name,
+		FeedURL:    source.RssFeedUrl,
+		StartedAt:  time.Now(),
+	}
+	defer func() {
+		result.DurationMs = time.Since(result.StartedAt).Milliseconds()
+	}()
+
+	if source.RssFeedUrl == "" {
+		log.Printf("Skipping source '%s' - no RSS feed URL", source.Name)
+		result.Status = ingestion.StatusSkipped
+		return result
+	}
+
+	host := feedHost(source.RssFeedUrl)
+	if err := limiter.acquire(ctx, host); err != nil {
+		result.Status = ingestion.StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+	defer limiter.release(host)
+
+	log.Printf("Ingesting RSS feed for source: %s (%s)", source.Name, source.RssFeedUrl)
+	feedResult, err := s.rssService.IngestSource(ctx, &source, s.config.RequestTimeout)
+	if feedResult != nil {
+		result.FeedResult = *feedResult
+	}
+
+	switch {
+	case err != nil:
+		log.Printf("Failed to ingest RSS feed for source '%s': %v", source.Name, err)
+		result.Status = ingestion.StatusFailed
+		result.Error = err.Error()
+	case result.NotModified:
+		result.Status = ingestion.StatusNotModified
+	default:
+		log.Printf("Successfully ingested RSS feed for source: %s", source.Name)
+		result.Status = ingestion.StatusSuccess
+	}
+
+	// A cancelled run says nothing about the health of the source
+	if ctx.Err() == nil {
+		s.trackFailures(&source, result)
+	}
+	return result
+}
+
+// hostLimiter bounds the number of concurrent requests made to each host
+type hostLimiter struct {
+	mu    sync.Mutex
+	limit int
+	slots map[string]chan struct{}
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	return &hostLimiter{limit: limit, slots: make(map[string]chan struct{})}
+}
+
+func (l *hostLimiter) slot(host string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ch, ok := l.slots[host]
+	if !ok {
+		ch = make(chan struct{}, l.limit)
+		l.slots[host] = ch
+	}
+	return ch
+}
+
+func (l *hostLimiter) acquire(ctx context.Context, host string) error {
+	select {
+	case l.slot(host) <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *hostLimiter) release(host string) {
+	<-l.slot(host)
+}
+
+// interleaveByHost orders sources round-robin by host so workers are not all
+// parked behind the per-host limit of one publisher with many feeds
+func interleaveByHost(sources []db.Source) []db.Source {
+	var hosts []string
+	byHost := make(map[string][]db.Source)
+	for _, source := range sources {
+		host := feedHost(source.RssFeedUrl)
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], source)
+	}
+
+	ordered := make([]db.Source, 0, len(sources))
+	for len(ordered) < len(sources) {
+		for _, host := range hosts {
+			if queue := byHost[host]; len(queue) > 0 {
+				ordered = append(ordered, queue[0])
+				byHost[host] = queue[1:]
+			}
+		}
+	}
+	return ordered
+}
+
+func feedHost(feedURL string) string {
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Host == "" {
+		return feedURL
+	}
+	return strings.ToLower(parsed.Hostname())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"time"
+	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
 	"vuka-api/pkg/repository"
 )
@@ -30,6 +31,11 @@ func (s *NewsletterService) GetAllSubscribers() ([]db.NewsletterSubscriber, erro
 	return s.repo.Newsletter.GetAllSubscribers()
 }
 
+// GetSubscribersAfter returns a keyset-paginated page of subscribers, newest first
+func (s *NewsletterService) GetSubscribersAfter(after *models.Cursor, limit int) ([]db.NewsletterSubscriber, *models.Cursor, error) {
+	return s.repo.Newsletter.GetSubscribersAfter(after, limit)
+}
+
 func (s *NewsletterService) GetSubscriberByID(id string) (*db.NewsletterSubscriber, error) {
 	return s.repo.Newsletter.GetSubscriberByID(id)
 }
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidRegion is returned when a region has no name or its slug is empty
+var ErrInvalidRegion = errors.New("region name and slug must not be blank")
+
+// ErrUnknownRegion is returned when an article is assigned a region that does not exist
+var ErrUnknownRegion = errors.New("region does not exist")
+
+// RegionService manages the provinces and other regions articles are filed under
+type RegionService struct {
+	repos *repository.Repositories
+}
+
+// NewRegionService creates a new RegionService.
+func NewRegionService(repos *repository.Repositories) *RegionService {
+	return &RegionService{repos: repos}
+}
+
+func (s *RegionService) GetAllRegions() ([]db.Region, error) {
+	return s.repos.Region.GetAll()
+}
+
+func (s *RegionService) GetRegionByID(id string) (*db.Region, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.repos.Region.GetByID(id)
+}
+
+func (s *RegionService) GetRegionBySlug(slug string) (*db.Region, error) {
+	return s.repos.Region.GetBySlug(strings.ToLower(slug))
+}
+
+// CreateRegion stores a region, deriving its slug from the name when none is given
+func (s *RegionService) CreateRegion(region *db.Region) error {
+	if err := normalizeRegion(region); err != nil {
+		return err
+	}
+	return s.repos.Region.Create(region)
+}
+
+// UpdateRegion renames a region, deriving its slug from the name when none is given
+func (s *RegionService) UpdateRegion(region *db.Region) error {
+	if err := normalizeRegion(region); err != nil {
+		return err
+	}
+	if _, err := s.repos.Region.GetByID(region.ID.String()); err != nil {
+		return err
+	}
+	return s.repos.Region.Update(region)
+}
+
+// DeleteRegion removes a region; its articles and sources keep no region
+func (s *RegionService) DeleteRegion(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return gorm.ErrRecordNotFound
+	}
+	return s.repos.Region.Delete(id)
+}
+
+// GetRegionArticles returns a page of the articles filed under the region with the given slug
+func (s *RegionService) GetRegionArticles(slug string, limit, offset int, query models.ArticleQuery) ([]db.Article, int64, error) {
+	query, err := s.regionQuery(slug, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repos.Article.GetAllWithRelationsByQuery(limit, offset, query)
+}
+
+// GetRegionArticlesAfter returns a keyset-paginated page of the articles filed under a region
+func (s *RegionService) GetRegionArticlesAfter(slug string, limit int, query models.ArticleQuery) ([]db.Article, *models.Cursor, error) {
+	query, err := s.regionQuery(slug, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.repos.Article.GetAllWithRelationsAfter(limit, query)
+}
+
+// regionQuery restricts an article query to the region with the given slug
+func (s *RegionService) regionQuery(slug string, query models.ArticleQuery) (models.ArticleQuery, error) {
+	region, err := s.GetRegionBySlug(slug)
+	if err != nil {
+		return query, err
+	}
+	query.RegionID = region.ID.String()
+	query.RegionSlug = ""
+	return query, nil
+}
+
+func normalizeRegion(region *db.Region) error {
+	region.Name = strings.TrimSpace(region.Name)
+	if region.Slug == "" {
+		region.Slug = region.Name
+	}
+	region.Slug = models.Slugify(region.Slug)
+	if region.Name == "" || region.Slug == "" {
+		return ErrInvalidRegion
+	}
+	return nil
+}
@@ -1,107 +1,351 @@
 package services
 
 import (
-	"encoding/xml"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"time"
 	"vuka-api/pkg/models"
 	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/models/ingestion"
 
 	"github.com/google/uuid"
 )
 
+// ErrFeedNotModified is returned by fetchFeed when the publisher answers 304
+var ErrFeedNotModified = errors.New("feed not modified")
+
+// itemEnrichTimeout bounds the page extraction and image downloads for one
+// saved article. It is separate from the fetch timeout, so a slow article page
+// never counts against the health of the feed it came from.
+const itemEnrichTimeout = 90 * time.Second
+
 type RssService struct {
 	articleService  *ArticleService
 	categoryService *CategoryService
+	mappingService  *CategoryMappingService
+	classifier      *ClassifierService
+	sourceService   *SourceService
+	clusterService  *ClusterService
+	extractor       *ExtractionService
+	imageService    *ImageService
+	client          *http.Client
+}
+
+// feedResponse holds a fetched feed body and the validators to send next time
+type feedResponse struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	StatusCode   int
+	FetchedAt    time.Time
 }
 
-func NewRssService(articleService *ArticleService, categoryService *CategoryService) *RssService {
+func NewRssService(articleService *ArticleService, categoryService *CategoryService, mappingService *CategoryMappingService, classifier *ClassifierService, sourceService *SourceService, clusterService *ClusterService, extractor *ExtractionService, imageService *ImageService) *RssService {
 	return &RssService{
 		articleService:  articleService,
 		categoryService: categoryService,
+		mappingService:  mappingService,
+		classifier:      classifier,
+		sourceService:   sourceService,
+		clusterService:  clusterService,
+		extractor:       extractor,
+		imageService:    imageService,
+		client:          &http.Client{Timeout: 2 * time.Minute},
 	}
 }
 
-func (s *RssService) IngestRSSFeed(url string) error {
-	return s.IngestRSSFeedWithSource(url, nil)
+func (s *RssService) IngestRSSFeed(ctx context.Context, url string) (*ingestion.FeedResult, error) {
+	return s.IngestRSSFeedWithSource(ctx, url, nil)
+}
+
+// IngestRSSFeedWithSource fetches a feed unconditionally and ingests its items
+func (s *RssService) IngestRSSFeedWithSource(ctx context.Context, url string, sourceID *uuid.UUID) (*ingestion.FeedResult, error) {
+	var source *db.Source
+	if sourceID != nil {
+		var err error
+		source, err = s.sourceService.GetSourceByID(sourceID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load source: %w", err)
+		}
+	}
+
+	resp, err := s.fetchFeed(ctx, url, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return s.ingestFeedBody(ctx, resp, source)
+}
+
+// IngestSource fetches a source's feed with If-None-Match/If-Modified-Since
+// and skips parsing entirely when the publisher reports it has not changed.
+// fetchTimeout bounds the download of the feed only, not the processing of its items.
+func (s *RssService) IngestSource(ctx context.Context, source *db.Source, fetchTimeout time.Duration) (*ingestion.FeedResult, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	resp, err := s.fetchFeed(fetchCtx, source.RssFeedUrl, source.ETag, source.LastModified)
+	cancel()
+	if errors.Is(err, ErrFeedNotModified) {
+		log.Printf("Feed for source '%s' not modified since last fetch", source.Name)
+		s.recordFetch(source, resp)
+		return &ingestion.FeedResult{StatusCode: resp.StatusCode, NotModified: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.ingestFeedBody(ctx, resp, source)
+	if err != nil {
+		return result, err
+	}
+
+	// Only store validators once the body has been ingested, so a failed
+	// parse is retried in full on the next run
+	s.recordFetch(source, resp)
+	return result, nil
 }
 
-func (s *RssService) IngestRSSFeedWithSource(url string, sourceID *uuid.UUID) error {
-	resp, err := http.Get(url)
+// fetchFeed downloads a feed, sending conditional headers when validators are known.
+// On 304 it returns the response metadata together with ErrFeedNotModified.
+func (s *RssService) fetchFeed(ctx context.Context, url, etag, lastModified string) (*feedResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to fetch RSS feed: %w", err)
+		return nil, fmt.Errorf("failed to build feed request: %w", err)
+	}
+	req.Header.Set("User-Agent", "VukaFeedFetcher/1.0")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/xml;q=0.9, */*;q=0.8")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	fetchedAt := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch RSS feed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	result := &feedResponse{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		StatusCode:   resp.StatusCode,
+		FetchedAt:    fetchedAt,
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		// Servers may omit validators on 304; keep the ones we sent
+		if result.ETag == "" {
+			result.ETag = etag
+		}
+		if result.LastModified == "" {
+			result.LastModified = lastModified
+		}
+		return result, ErrFeedNotModified
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch RSS feed: unexpected status %s", resp.Status)
+	}
+
+	result.Body, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	var rss models.RSS
-	err = xml.Unmarshal(body, &rss)
+	return result, nil
+}
+
+// recordFetch persists the validators and fetch time for a source
+func (s *RssService) recordFetch(source *db.Source, resp *feedResponse) {
+	source.ETag = resp.ETag
+	source.LastModified = resp.LastModified
+	source.LastFetchedAt = &resp.FetchedAt
+	source.LastStatusCode = resp.StatusCode
+
+	err := s.sourceService.RecordFetch(source.ID.String(), map[string]any{
+		"etag":             source.ETag,
+		"last_modified":    source.LastModified,
+		"last_fetched_at":  source.LastFetchedAt,
+		"last_status_code": source.LastStatusCode,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to parse RSS feed: %w", err)
+		log.Printf("Failed to record fetch state for source '%s': %v", source.Name, err)
 	}
+}
 
-	fmt.Printf("Feed Title: %s\n", rss.Channel.Title)
-	fmt.Printf("Number of Items: %d\n", len(rss.Channel.Items))
+// extractFullText replaces a new or revised article's feed content with the text
+// extracted from its web page, for sources that opt in. Failures keep the feed
+// content. The extracted page is returned so its lead image can be reused.
+func (s *RssService) extractFullText(ctx context.Context, source *db.Source, article *db.Article) *models.ExtractedArticle {
+	if source == nil || !source.ExtractFullText || article.OriginalUrl == "" {
+		return nil
+	}
 
-	savedCount := 0
-	duplicateCount := 0
+	extracted, err := s.extractor.ExtractFromURL(ctx, article.OriginalUrl)
+	if err != nil {
+		log.Printf("Failed to extract full text for article '%s': %v", article.Title, err)
+		return nil
+	}
 
-	// Save all articles from the RSS feed
-	for i, item := range rss.Channel.Items {
-		article, err := item.ToArticle(rss.Channel.Language)
+	if err := s.articleService.ApplyExtractedContent(article, extracted); err != nil {
+		log.Printf("Failed to store extracted text for article '%s': %v", article.Title, err)
+	}
+	return extracted
+}
+
+// addLeadImage gives a new article whose feed item carried no usable image the
+// og:image of its web page, reusing the extracted page when there is one
+func (s *RssService) addLeadImage(ctx context.Context, article *db.Article, extracted *models.ExtractedArticle) {
+	if len(article.Images) > 0 || article.OriginalUrl == "" {
+		return
+	}
+
+	var imageURL string
+	if extracted != nil {
+		imageURL = extracted.ImageURL
+	} else {
+		var err error
+		imageURL, err = s.extractor.FetchLeadImage(ctx, article.OriginalUrl)
+		if err != nil {
+			log.Printf("Failed to fetch lead image for article '%s': %v", article.Title, err)
+			return
+		}
+	}
+	if imageURL == "" {
+		return
+	}
+
+	if err := s.articleService.AddMainImage(article, imageURL); err != nil {
+		log.Printf("Failed to store lead image for article '%s': %v", article.Title, err)
+	}
+}
+
+// enrichArticle extracts the full text of a saved article and, for new ones,
+// fetches and stores its images. It runs under its own deadline; the article
+// is already saved, so running out of time only loses the extras.
+func (s *RssService) enrichArticle(ctx context.Context, source *db.Source, article *db.Article, created bool) {
+	itemCtx, cancel := context.WithTimeout(ctx, itemEnrichTimeout)
+	defer cancel()
+
+	extracted := s.extractFullText(itemCtx, source, article)
+	if !created {
+		return
+	}
+	s.addLeadImage(itemCtx, article, extracted)
+	s.imageService.StoreArticleImages(itemCtx, article)
+}
+
+// resolveCategories finds or creates a category for each name
+func (s *RssService) resolveCategories(names []string) ([]db.Category, error) {
+	categories := make([]db.Category, 0, len(names))
+	for _, name := range names {
+		category, err := s.categoryService.FindOrCreate(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find or create category '%s': %w", name, err)
+		}
+		categories = append(categories, *category)
+	}
+	return categories, nil
+}
+
+// ingestFeedBody parses a fetched feed and saves its items. source may be nil
+// for feeds ingested by URL alone.
+func (s *RssService) ingestFeedBody(ctx context.Context, resp *feedResponse, source *db.Source) (*ingestion.FeedResult, error) {
+	result := &ingestion.FeedResult{StatusCode: resp.StatusCode}
+
+	feed, err := models.ParseFeed(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	fmt.Printf("Feed Title: %s (%s)\n", feed.Title, feed.Format)
+	fmt.Printf("Number of Items: %d\n", len(feed.Items))
+	result.ItemsSeen = len(feed.Items)
+
+	// Group categories with the editor-managed mapping rules
+	mapper := s.mappingService.Mapper()
+
+	// Save all articles from the feed
+	for i, item := range feed.Items {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("ingestion interrupted after %d of %d items: %w", i, len(feed.Items), err)
+		}
+
+		pubDate := item.PublishedAt(resp.FetchedAt)
+		if pubDate.Estimated {
+			log.Printf("Unparseable pubDate %q for item '%s', using fetch time", item.PubDate, item.Title)
+			result.DatesRescued++
+		} else if pubDate.Rescued {
+			log.Printf("Rescued pubDate %q for item '%s' using layout %q", item.PubDate, item.Title, pubDate.Layout)
+			result.DatesRescued++
+		}
+
+		article, err := item.ToArticle(feed.Language, pubDate)
 		if err != nil {
-			log.Printf("Failed to convert RSS item %d to article: %v", i, err)
+			log.Printf("Failed to convert feed item %d to article: %v", i, err)
+			result.ItemsFailed++
 			continue
 		}
 
-		// Set the source ID if provided
-		if sourceID != nil {
-			article.SourceID = sourceID
+		// Set the source ID and the source's defaults if provided
+		if source != nil {
+			article.SourceID = &source.ID
+			source.ApplyDefaults(article)
+		}
+
+		groupedCategoryNames := mapper.MapCategories(item.Categories)
+		if len(groupedCategoryNames) == 0 && source != nil && source.DefaultCategory != "" {
+			// Says nothing about the text, so it is kept out of classifier training
+			groupedCategoryNames = []string{source.DefaultCategory}
+			article.CategoryDefaulted = true
+		}
+		if len(groupedCategoryNames) == 0 {
+			// Nothing usable in the feed's tags; predict from the text instead
+			groupedCategoryNames = s.classifier.InferCategories(article)
+			article.CategoriesInferred = len(groupedCategoryNames) > 0
+		}
+
+		// Resolve categories up front; the article is only saved once all of
+		// them exist so it is never stored with a partial set
+		categories, err := s.resolveCategories(groupedCategoryNames)
+		if err != nil {
+			log.Printf("Failed to resolve categories for article '%s': %v", article.Title, err)
+			result.ItemsFailed++
+			continue
 		}
 
-		created, err := s.articleService.CreateArticleIfNotExists(article)
+		outcome, err := s.articleService.SaveFromFeed(article, categories)
 		if err != nil {
 			log.Printf("Failed to save article '%s': %v", article.Title, err)
+			result.ItemsFailed++
 			continue
 		}
 
-		if created {
+		switch outcome {
+		case ArticleCreated:
 			fmt.Printf("Successfully saved article: %s\n", article.Title)
-			savedCount++
-
-			// Use category mapper to group categories
-			mapper := models.NewCategoryMapper()
-			groupedCategoryNames := mapper.MapCategories(item.Categories)
-
-			// Find or create categories and associate them with the article
-			var categories []db.Category
-			for _, categoryName := range groupedCategoryNames {
-				category, err := s.categoryService.FindOrCreate(categoryName)
-				if err != nil {
-					log.Printf("Failed to find or create category '%s': %v", categoryName, err)
-					continue
-				}
-				categories = append(categories, *category)
-			}
+			result.ItemsNew++
 
-			if len(categories) > 0 {
-				if err := s.articleService.SetArticleCategories(article, categories); err != nil {
-					log.Printf("Failed to set categories for article '%s': %v", article.Title, err)
-				}
+			if err := s.clusterService.AssignCluster(article); err != nil {
+				log.Printf("Failed to cluster article '%s': %v", article.Title, err)
 			}
-		} else {
+			s.enrichArticle(ctx, source, article, true)
+		case ArticleUpdated:
+			log.Printf("Article changed since last fetch, recorded revision: %s", article.Title)
+			result.ItemsUpdated++
+			s.enrichArticle(ctx, source, article, false)
+		default:
 			log.Printf("Article already exists, skipping: %s", article.Title)
-			duplicateCount++
+			result.ItemsDuplicate++
 		}
 	}
 
-	fmt.Printf("RSS ingestion completed. New articles: %d, Duplicates skipped: %d\n", savedCount, duplicateCount)
+	fmt.Printf("Feed ingestion completed. New articles: %d, Updated: %d, Duplicates skipped: %d, Dates rescued: %d\n", result.ItemsNew, result.ItemsUpdated, result.ItemsDuplicate, result.DatesRescued)
 
-	return nil
+	return result, nil
 }
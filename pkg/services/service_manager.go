@@ -1,46 +1,75 @@
 package services
 
 import (
+	"log"
 	"vuka-api/pkg/repository"
+	"vuka-api/pkg/storage"
 
 	"gorm.io/gorm"
 )
 
 type Services struct {
-	Article    *ArticleService
-	User       *UserService
-	Auth       *AuthService
-	Role       *RoleService
-	Rss        *RssService
-	Source     *SourceService
-	Directory  *DirectoryService
-	Cron       *CronService
-	Category   *CategoryService
-	Permission *PermissionService
-	Newsletter *NewsletterService
+	Article     *ArticleService
+	User        *UserService
+	Auth        *AuthService
+	Role        *RoleService
+	Rss         *RssService
+	Source      *SourceService
+	Directory   *DirectoryService
+	Cron        *CronService
+	Ingestion   *IngestionService
+	Category    *CategoryService
+	Permission  *PermissionService
+	Newsletter  *NewsletterService
+	Cluster     *ClusterService
+	Extraction  *ExtractionService
+	Image       *ImageService
+	Mapping     *CategoryMappingService
+	Classifier  *ClassifierService
+	Region      *RegionService
+	Syndication *SyndicationService
 }
 
 func NewServices(db *gorm.DB) *Services {
 	repos := repository.NewRepositories(db)
 
+	mediaStorage, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure media storage: %v", err)
+	}
+
 	articleService := NewArticleService(repos)
-	sourceService := NewSourceService(repos)
+	clusterService := NewClusterService(repos)
+	extractionService := NewExtractionService()
+	imageService := NewImageService(repos, mediaStorage)
 	categoryService := NewCategoryService(repos.Category)
-	rssService := NewRssService(articleService, categoryService)
+	mappingService := NewCategoryMappingService(repos.Mapping)
+	sourceService := NewSourceService(repos, mappingService)
+	classifierService := NewClassifierService(repos, mappingService)
+	rssService := NewRssService(articleService, categoryService, mappingService, classifierService, sourceService, clusterService, extractionService, imageService)
 	directoryService := NewDirectoryService(repos.Directory)
 	newsletterService := NewNewsletterService(repos)
+	ingestionService := NewIngestionService(repos.Ingestion, rssService, sourceService, LoadIngestionConfig())
 
 	return &Services{
-		Article:    articleService,
-		User:       NewUserService(repos),
-		Auth:       NewAuthService(repos),
-		Role:       NewRoleService(repos),
-		Rss:        rssService,
-		Source:     sourceService,
-		Cron:       NewCronService(rssService, sourceService, newsletterService),
-		Category:   categoryService,
-		Directory:  directoryService,
-		Permission: NewPermissionService(repos),
-		Newsletter: newsletterService,
+		Article:     articleService,
+		User:        NewUserService(repos),
+		Auth:        NewAuthService(repos),
+		Role:        NewRoleService(repos),
+		Rss:         rssService,
+		Source:      sourceService,
+		Cron:        NewCronService(ingestionService, newsletterService),
+		Ingestion:   ingestionService,
+		Category:    categoryService,
+		Directory:   directoryService,
+		Permission:  NewPermissionService(repos),
+		Newsletter:  newsletterService,
+		Cluster:     clusterService,
+		Extraction:  extractionService,
+		Image:       imageService,
+		Mapping:     mappingService,
+		Classifier:  classifierService,
+		Region:      NewRegionService(repos),
+		Syndication: NewSyndicationService(repos),
 	}
 }
@@ -1,19 +1,32 @@
 package services
 
 import (
+	"errors"
+	"strings"
 	"vuka-api/pkg/models/db"
 	"vuka-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// ErrUnknownCategoryGroup is returned when a source's default category is not a category group
+var ErrUnknownCategoryGroup = errors.New("default category must name a category group")
+
 type SourceService struct {
-	repos *repository.Repositories
+	repos          *repository.Repositories
+	mappingService *CategoryMappingService
 }
 
-func NewSourceService(repos *repository.Repositories) *SourceService {
-	return &SourceService{repos: repos}
+func NewSourceService(repos *repository.Repositories, mappingService *CategoryMappingService) *SourceService {
+	return &SourceService{repos: repos, mappingService: mappingService}
 }
 
 func (s *SourceService) CreateSource(source *db.Source) error {
+	source.ClearFetchState()
+	if err := s.validateDefaults(source); err != nil {
+		return err
+	}
 	return s.repos.Source.Create(source)
 }
 
@@ -25,10 +38,73 @@ func (s *SourceService) GetAllSources() ([]db.Source, error) {
 	return s.repos.Source.GetAll()
 }
 
-func (s *SourceService) UpdateSource(source *db.Source) error {
-	return s.repos.Source.Update(source)
+// UpdateSource saves the editor-managed fields of a source and returns the
+// stored source; fetch and failure state is left untouched
+func (s *SourceService) UpdateSource(source *db.Source) (*db.Source, error) {
+	if err := s.validateDefaults(source); err != nil {
+		return nil, err
+	}
+	if err := s.repos.Source.Update(source); err != nil {
+		return nil, err
+	}
+	return s.repos.Source.GetByID(source.ID.String())
+}
+
+// RecordFetch persists conditional fetch state for a source
+func (s *SourceService) RecordFetch(id string, updates map[string]any) error {
+	return s.repos.Source.UpdateFields(id, updates)
+}
+
+// EnableSource clears the disabled state and failure history of a source
+func (s *SourceService) EnableSource(id string) (*db.Source, error) {
+	err := s.repos.Source.UpdateFields(id, map[string]any{
+		"disabled":             false,
+		"disabled_at":          nil,
+		"disabled_reason":      "",
+		"consecutive_failures": 0,
+		"next_fetch_at":        nil,
+		"last_error":           "",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repos.Source.GetByID(id)
 }
 
 func (s *SourceService) DeleteSource(id string) error {
 	return s.repos.Source.Delete(id)
 }
+
+// validateDefaults checks that a source's default category is one of the
+// category groups, storing the group's own spelling, and that its default
+// region exists. Ingestion would otherwise create a stray category.
+func (s *SourceService) validateDefaults(source *db.Source) error {
+	if name := strings.TrimSpace(source.DefaultCategory); name != "" {
+		source.DefaultCategory = ""
+		for _, group := range s.mappingService.Mapper().Groups() {
+			if strings.EqualFold(group, name) {
+				source.DefaultCategory = group
+				break
+			}
+		}
+		if source.DefaultCategory == "" {
+			return ErrUnknownCategoryGroup
+		}
+	}
+
+	if source.DefaultRegionID == nil {
+		return nil
+	}
+	if *source.DefaultRegionID == "" {
+		source.DefaultRegionID = nil
+		return nil
+	}
+	if _, err := uuid.Parse(*source.DefaultRegionID); err != nil {
+		return ErrUnknownRegion
+	}
+	_, err := s.repos.Region.GetByID(*source.DefaultRegionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUnknownRegion
+	}
+	return err
+}
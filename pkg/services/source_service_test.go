@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository"
+	"vuka-api/pkg/repository/contracts"
+)
+
+type fakeMappingRepository struct {
+	contracts.CategoryMappingRepository
+	groups []db.CategoryGroup
+}
+
+func (r *fakeMappingRepository) GetAllGroups() ([]db.CategoryGroup, error) {
+	return r.groups, nil
+}
+
+func TestValidateDefaults_DefaultCategory(t *testing.T) {
+	mappingService := NewCategoryMappingService(&fakeMappingRepository{groups: []db.CategoryGroup{{Name: "Politics"}, {Name: "Sport"}}})
+	if err := mappingService.Reload(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	service := NewSourceService(&repository.Repositories{}, mappingService)
+
+	tests := []struct {
+		name     string
+		category string
+		expected string
+		err      error
+	}{
+		{name: "No default", category: "", expected: ""},
+		{name: "Group name", category: "Sport", expected: "Sport"},
+		{name: "Different case", category: " politics ", expected: "Politics"},
+		{name: "Unknown group", category: "Sports News", err: ErrUnknownCategoryGroup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &db.Source{DefaultCategory: tt.category}
+			err := service.validateDefaults(source)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if err == nil && source.DefaultCategory != tt.expected {
+				t.Errorf("Expected default category %q, got %q", tt.expected, source.DefaultCategory)
+			}
+		})
+	}
+}
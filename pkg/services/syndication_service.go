@@ -0,0 +1,80 @@
+package services
+
+import (
+	"vuka-api/pkg/models"
+	"vuka-api/pkg/models/db"
+	"vuka-api/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+// syndicationFeedSize is how many articles an output feed carries
+const syndicationFeedSize = 50
+
+// Only what the feed formats need is loaded; article bodies stay with the publisher
+var (
+	syndicationFields   = []string{"id", "title", "summary", "originalUrl", "publishedAt", "updatedAt", "revisedAt"}
+	syndicationIncludes = []string{"source", "images", "categories"}
+)
+
+// SyndicationService builds the RSS and Atom feeds partners syndicate Vuka's curated articles from
+type SyndicationService struct {
+	repos *repository.Repositories
+}
+
+// NewSyndicationService creates a new SyndicationService.
+func NewSyndicationService(repos *repository.Repositories) *SyndicationService {
+	return &SyndicationService{repos: repos}
+}
+
+// LatestFeed returns the most recently published articles
+func (s *SyndicationService) LatestFeed() (*models.OutputFeed, error) {
+	return s.feed("Vuka: Latest news", "The latest news curated by Vuka", models.ArticleQuery{})
+}
+
+// FeaturedFeed returns the articles editors have featured
+func (s *SyndicationService) FeaturedFeed() (*models.OutputFeed, error) {
+	featured := true
+	return s.feed("Vuka: Featured", "Stories featured by Vuka's editors", models.ArticleQuery{Featured: &featured})
+}
+
+// CategoryFeed returns the latest articles in a category
+func (s *SyndicationService) CategoryFeed(id string) (*models.OutputFeed, error) {
+	var categories []db.Category
+	if err := s.repos.Category.FindIn("id", []any{id}, &categories); err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	category := categories[0]
+	return s.feed("Vuka: "+category.Name, "The latest "+category.Name+" news curated by Vuka",
+		models.ArticleQuery{CategoryIDs: []string{category.ID.String()}})
+}
+
+// RegionFeed returns the latest articles filed under the region with the given slug
+func (s *SyndicationService) RegionFeed(slug string) (*models.OutputFeed, error) {
+	region, err := s.repos.Region.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.feed("Vuka: "+region.Name, "The latest news from "+region.Name+" curated by Vuka",
+		models.ArticleQuery{RegionID: region.ID.String()})
+}
+
+// feed loads the newest published articles matching the query, one per story
+func (s *SyndicationService) feed(title, description string, query models.ArticleQuery) (*models.OutputFeed, error) {
+	fieldSet, err := models.NewArticleFieldSet(syndicationFields, syndicationIncludes)
+	if err != nil {
+		return nil, err
+	}
+	query.FieldSet = fieldSet
+	query.Sort = models.SortPublishedAt
+	query.CollapseClusters = true
+
+	articles, _, err := s.repos.Article.GetAllWithRelationsAfter(syndicationFeedSize, query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OutputFeed{Title: title, Description: description, Articles: articles}, nil
+}
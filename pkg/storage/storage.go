@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrNotFound is returned by Open when no object is stored under a key
+var ErrNotFound = errors.New("object not found")
+
+// Storage stores binary objects such as images under slash separated keys
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader) error
+	// Open returns the object's contents. Backends return an io.ReadSeeker
+	// where they can so range and conditional requests can be served.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFromEnv creates the backend named by MEDIA_STORAGE, which defaults to
+// "local". The local backend writes under MEDIA_STORAGE_DIR, default "./media".
+func NewFromEnv() (Storage, error) {
+	switch backend := os.Getenv("MEDIA_STORAGE"); backend {
+	case "", "local":
+		dir := os.Getenv("MEDIA_STORAGE_DIR")
+		if dir == "" {
+			dir = "./media"
+		}
+		return NewLocalStorage(dir), nil
+	default:
+		return nil, fmt.Errorf("unknown media storage backend %q", backend)
+	}
+}
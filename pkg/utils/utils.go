@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"time"
+	"vuka-api/pkg/models"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -30,6 +31,20 @@ type PaginatedResponse struct {
 	Pagination PaginationResult `json:"pagination"`
 }
 
+// CursorPaginationResult represents keyset pagination metadata. Pass
+// NextCursor back as the cursor parameter to fetch the following page.
+type CursorPaginationResult struct {
+	PageSize   int    `json:"pageSize"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+// CursorPaginatedResponse represents a keyset-paginated API response
+type CursorPaginatedResponse struct {
+	Data       interface{}            `json:"data"`
+	Pagination CursorPaginationResult `json:"pagination"`
+}
+
 // GetPaginationParams extracts and validates pagination parameters from query strings
 func GetPaginationParams(pageStr, pageSizeStr string) PaginationParams {
 	page, err := strconv.Atoi(pageStr)
@@ -66,6 +81,17 @@ func CreatePaginationResult(page, pageSize int, totalItems int64) PaginationResu
 	}
 }
 
+// CreateCursorPaginationResult creates keyset pagination metadata from the
+// cursor of the next page, which is nil on the last page
+func CreateCursorPaginationResult(pageSize int, next *models.Cursor) CursorPaginationResult {
+	result := CursorPaginationResult{PageSize: pageSize}
+	if next != nil {
+		result.NextCursor = next.Encode()
+		result.HasMore = true
+	}
+	return result
+}
+
 func GenerateTokenString(userId uuid.UUID, roleId uuid.UUID, roleName string, expDate time.Time) (string, error) {
 	// Generate a JWT token
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{